@@ -0,0 +1,26 @@
+package eros
+
+import (
+	"reflect"
+	"runtime"
+)
+
+// All - runs fns in order, stopping at the first one to fail. The failure is wrapped
+// with the index and name of the step so a long init sequence tells you exactly where
+// it stopped, and nil is returned when every step succeeds
+func All(fns ...func() error) *Error {
+	for i, fn := range fns {
+		if err := fn(); err != nil {
+			return Wrapf(err, "step %d (%s) failed", i, funcName(fn))
+		}
+	}
+	return nil
+}
+
+// funcName - the name of the function behind fn, as the runtime knows it
+func funcName(fn interface{}) string {
+	if f := runtime.FuncForPC(reflect.ValueOf(fn).Pointer()); f != nil {
+		return f.Name()
+	}
+	return "unknown"
+}
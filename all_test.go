@@ -0,0 +1,65 @@
+package eros
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+var errStepFailed = errors.New("step failed")
+
+func stepOK() error { return nil }
+
+func stepFails() error { return errStepFailed }
+
+func TestAll(t *testing.T) {
+	ran := 0
+	count := func() error {
+		ran++
+		return nil
+	}
+	tests := []struct {
+		name    string
+		fns     []func() error
+		wantRan int
+		wantMsg string
+	}{
+		{
+			"Test every step succeeds",
+			[]func() error{count, stepOK, count},
+			2,
+			"",
+		},
+		{
+			"Test stops at the first failure",
+			[]func() error{count, stepFails, count},
+			1,
+			"step 1 (github.com/dawenga/eros.stepFails) failed",
+		},
+		{
+			"Test nothing to run",
+			nil,
+			0,
+			"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ran = 0
+			err := All(tt.fns...)
+			if ran != tt.wantRan {
+				t.Errorf("All() ran %d steps, want %d", ran, tt.wantRan)
+			}
+			if tt.wantMsg == "" {
+				if err != nil {
+					t.Errorf("All() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantMsg) || !Is(err, errStepFailed) {
+				t.Errorf("All() = %v, want %q wrapping %v", err, tt.wantMsg, errStepFailed)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package eros
+
+import "sync/atomic"
+
+// DropPolicy - what an AsyncHandler does with an error when its queue is full
+type DropPolicy int
+
+const (
+	// DropNewest - the error being handled is dropped, the queue is left alone
+	DropNewest DropPolicy = iota
+	// DropOldest - the longest waiting error is dropped to make room
+	DropOldest
+)
+
+// asyncDropped - errors dropped by every AsyncHandler, see AsyncDropped
+var asyncDropped uint64
+
+// AsyncDropped - the number of errors AsyncHandlers have dropped because their queue
+// was full. Export this as a metric; if it moves, the sink can't keep up
+func AsyncDropped() uint64 {
+	return atomic.LoadUint64(&asyncDropped)
+}
+
+// AsyncHandler - hands errors to inner on a worker goroutine of its own, so a slow
+// sink (e.g. a network log shipper) never blocks the recover path. At most queue
+// errors wait for the worker, beyond that drop decides what is lost; a queue below 1
+// is taken as 1. The worker lives as long as the process, so make one per sink, not
+// one per call
+func AsyncHandler(inner Handler, queue int, drop DropPolicy) Handler {
+	if queue < 1 {
+		// unbuffered, DropOldest would never find anything to drop and spin
+		queue = 1
+	}
+	ch := make(chan *Error, queue)
+	go func() {
+		for err := range ch {
+			inner(err)
+		}
+	}()
+	return func(err *Error) {
+		for {
+			select {
+			case ch <- err:
+				return
+			default:
+			}
+			if drop == DropNewest {
+				atomic.AddUint64(&asyncDropped, 1)
+				return
+			}
+			// make room, we may race another sender for it so go around again
+			select {
+			case <-ch:
+				atomic.AddUint64(&asyncDropped, 1)
+			default:
+			}
+		}
+	}
+}
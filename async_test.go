@@ -0,0 +1,79 @@
+package eros
+
+import (
+	"testing"
+)
+
+func TestAsyncHandler(t *testing.T) {
+	tests := []struct {
+		name string
+		drop DropPolicy
+		want []string
+	}{
+		{
+			"Test DropNewest keeps the first errors",
+			DropNewest,
+			[]string{"blocker", "1", "2"},
+		},
+		{
+			"Test DropOldest keeps the last errors",
+			DropOldest,
+			[]string{"blocker", "3", "4"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			started, release := make(chan struct{}), make(chan struct{})
+			got := make(chan string, 10)
+			h := AsyncHandler(func(err *Error) {
+				if err.msg == "blocker" {
+					close(started)
+					<-release
+				}
+				got <- err.msg
+			}, 2, tt.drop)
+
+			before := AsyncDropped()
+			h(New("blocker"))
+			<-started
+			for _, msg := range []string{"1", "2", "3", "4"} {
+				h(New(msg))
+			}
+			if dropped := AsyncDropped() - before; dropped != 2 {
+				t.Errorf("AsyncDropped() moved by %d, want 2", dropped)
+			}
+			close(release)
+			for _, want := range tt.want {
+				if msg := <-got; msg != want {
+					t.Errorf("handled %q, want %q", msg, want)
+				}
+			}
+		})
+	}
+}
+
+func TestAsyncHandlerEmptyQueue(t *testing.T) {
+	for _, queue := range []int{0, -1} {
+		started, release := make(chan struct{}), make(chan struct{})
+		got := make(chan string, 10)
+		h := AsyncHandler(func(err *Error) {
+			if err.msg == "blocker" {
+				close(started)
+				<-release
+			}
+			got <- err.msg
+		}, queue, DropOldest)
+
+		h(New("blocker"))
+		<-started
+		// with the worker busy, these have to queue or drop rather than block
+		h(New("1"))
+		h(New("2"))
+		close(release)
+		for _, want := range []string{"blocker", "2"} {
+			if msg := <-got; msg != want {
+				t.Errorf("queue %d: handled %q, want %q", queue, msg, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package eros
+
+// Logger - where a Bound logs; an entry for err, at the level its severity says, with
+// msg as the entry's message
+type Logger interface {
+	LogError(sev Severity, msg string, err *Error)
+}
+
+// Bound - Wrap, Check and Handle bound to a Logger, so every wrap point also logs in
+// the same call, see Bind
+type Bound struct {
+	logger Logger
+	fields map[string]interface{}
+}
+
+// Bind - Wrap, Check and Handle which log what they wrap, raise or handle to logger
+//
+//	log := eros.Bind(logger).WithField("component", "billing")
+//	return log.Wrap(err, "charging card")
+func Bind(logger Logger) *Bound {
+	return &Bound{logger: logger}
+}
+
+// WithField - a Bound which also adds key to the fields of every error it sees, e.g.
+// the component or request id. b itself is left as it was
+func (b *Bound) WithField(key string, value interface{}) *Bound {
+	fields := make(map[string]interface{}, len(b.fields)+1)
+	for k, v := range b.fields {
+		fields[k] = v
+	}
+	fields[key] = value
+	return &Bound{logger: b.logger, fields: fields}
+}
+
+// Wrap - Wrap, logging the wrapped error with msg. Logging it doesn't handle it, it's
+// still the caller's to deal with. nil for nil
+func (b *Bound) Wrap(err error, msg string) *Error {
+	if err == nil {
+		return nil
+	}
+	e := wrap(err, msg, 1).WithFields(b.fields)
+	countError(e)
+	b.log(msg, e)
+	return e
+}
+
+// Check - Check, logging the error before it's raised
+func (b *Bound) Check(err error, mesgs ...string) {
+	if err != nil {
+		countCheck(1)
+		e := b.enrich(CastOrWrap(err, mesgs...))
+		b.log(e.text(), e)
+		raise(e)
+	}
+}
+
+// Handle - handler, logging every error before handing it on, for ErrorHandler or
+// Result.Handle. A nil handler only logs
+func (b *Bound) Handle(handler Handler) Handler {
+	return func(err *Error) {
+		b.enrich(err)
+		b.log("handled", err)
+		if handler != nil {
+			handler(err)
+		}
+	}
+}
+
+// enrich - err with the bound fields added to a map of its own; a cast shares its
+// fields with the error it was cast from, which is left alone
+func (b *Bound) enrich(err *Error) *Error {
+	if err == nil || len(b.fields) == 0 {
+		return err
+	}
+	fields := make(map[string]interface{}, len(err.fields)+len(b.fields))
+	for k, v := range err.fields {
+		fields[k] = v
+	}
+	err.fields = fields
+	return err.WithFields(b.fields)
+}
+
+// log - hands err to the logger at its severity
+func (b *Bound) log(msg string, err *Error) {
+	b.logger.LogError(err.Severity(), msg, err)
+}
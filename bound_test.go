@@ -0,0 +1,78 @@
+package eros
+
+import (
+	"io"
+	"testing"
+)
+
+// entry - what a logger was handed
+type entry struct {
+	sev Severity
+	msg string
+	err *Error
+}
+
+// recordingLogger - a Logger keeping its entries
+type recordingLogger struct {
+	entries []entry
+}
+
+func (l *recordingLogger) LogError(sev Severity, msg string, err *Error) {
+	l.entries = append(l.entries, entry{sev, msg, err})
+}
+
+func TestBind(t *testing.T) {
+	logger := &recordingLogger{}
+	log := Bind(logger).WithField("component", "billing")
+
+	if log.Wrap(nil, "charging card") != nil {
+		t.Errorf("Wrap(nil) should be nil")
+	}
+	wrapped := log.Wrap(io.EOF, "charging card")
+	if !Is(wrapped, io.EOF) || wrapped.Fields()["component"] != "billing" {
+		t.Errorf("Wrap() = %v, want the error wrapped and enriched", wrapped)
+	}
+
+	var handled *Error
+	func() {
+		defer ErrorHandler(log.Handle(func(err *Error) { handled = err }))()
+		log.Check(New("card declined").WithSeverity(Warning))
+	}()
+	if handled == nil || handled.msg != "card declined" {
+		t.Errorf("expected the raised error handed on, got %v", handled)
+	}
+
+	if len(logger.entries) != 3 {
+		t.Fatalf("expected the wrap, the check and the handling logged, got %v", logger.entries)
+	}
+	wrap, check, handle := logger.entries[0], logger.entries[1], logger.entries[2]
+	if wrap.msg != "charging card" || wrap.sev != Normal {
+		t.Errorf("wrap logged %+v", wrap)
+	}
+	if check.msg != "card declined" || check.sev != Warning || check.err.Fields()["component"] != "billing" {
+		t.Errorf("check logged %+v", check)
+	}
+	if handle.msg != "handled" || handle.sev != Warning {
+		t.Errorf("handling logged %+v", handle)
+	}
+
+	if Bind(logger).fields != nil {
+		t.Errorf("expected WithField to leave the Bound it was called on alone")
+	}
+}
+
+func TestBindLeavesInputAlone(t *testing.T) {
+	log := Bind(&recordingLogger{}).WithField("component", "billing")
+	orig := New("card declined").WithField("card", "visa")
+	var handled *Error
+	func() {
+		defer ErrorHandler(log.Handle(func(err *Error) { handled = err }))()
+		log.Check(orig)
+	}()
+	if handled.Fields()["component"] != "billing" {
+		t.Errorf("expected the raised error enriched, got %v", handled.Fields())
+	}
+	if _, ok := orig.fields["component"]; ok || len(orig.fields) != 1 {
+		t.Errorf("expected the error checked left alone, got %v", orig.fields)
+	}
+}
@@ -0,0 +1,69 @@
+package eros
+
+import "sync"
+
+// BoundaryPolicy - what becomes of errors crossing into a package, see RegisterBoundary
+type BoundaryPolicy struct {
+	// Layer - the name errors crossing are annotated with, the package's if empty
+	Layer string
+	// Profile - what's kept of errors crossing, see Sanitize. nil keeps everything
+	Profile *Profile
+	// MaxDepth - the most links kept of errors crossing, the rest summarized by one, see
+	// LimitDepth. The layer's own link is on top of these. 0 keeps them all
+	MaxDepth int
+}
+
+var (
+	boundariesMu sync.RWMutex
+	boundaries   = map[string]BoundaryPolicy{}
+)
+
+// RegisterBoundary - declares the policy for errors crossing into pkg, replacing
+// whatever was declared for it before. A service declares its layers once, at start up,
+// rather than every call site remembering to wrap and sanitize
+func RegisterBoundary(pkg string, policy BoundaryPolicy) {
+	boundariesMu.Lock()
+	defer boundariesMu.Unlock()
+	boundaries[pkg] = policy
+}
+
+// Crossing - the boundary of a package, see Boundary
+type Crossing struct {
+	pkg string
+}
+
+// Boundary - the boundary of pkg, typically kept in a package level var and used
+// wherever errors leave the package. The policy is looked up as errors cross, so it's
+// fine to call this before RegisterBoundary
+func Boundary(pkg string) Crossing {
+	return Crossing{pkg: pkg}
+}
+
+// Wrap - err as it crosses the boundary; sanitized as the policy says and wrapped in a
+// link named after the layer, which is also in the field "layer". An error that
+// already crossed it, its outermost link being that of this layer, is returned as is.
+// nil for nil
+func (c Crossing) Wrap(err error) error {
+	if err == nil {
+		return nil
+	}
+	boundariesMu.RLock()
+	policy, ok := boundaries[c.pkg]
+	boundariesMu.RUnlock()
+	layer := c.pkg
+	if ok && policy.Layer != "" {
+		layer = policy.Layer
+	}
+	if e := link(err); e != nil && e.fields["layer"] == layer {
+		return err
+	}
+	if policy.Profile != nil {
+		err = Sanitize(err, *policy.Profile)
+	}
+	if policy.MaxDepth > 0 {
+		err = LimitDepth(err, policy.MaxDepth)
+	}
+	e := wrap(err, layer, 1).WithField("layer", layer)
+	countError(e)
+	return e
+}
@@ -0,0 +1,36 @@
+package eros
+
+import "testing"
+
+func TestBoundary(t *testing.T) {
+	RegisterBoundary("example.com/app/storage", BoundaryPolicy{Layer: "storage", Profile: &PartnerProfile})
+	storage := Boundary("example.com/app/storage")
+
+	if err := storage.Wrap(nil); err != nil {
+		t.Errorf("Wrap(nil) = %v, want nil", err)
+	}
+
+	inner := New("connection refused").WithField("dsn", "postgres://secret")
+	err := storage.Wrap(inner)
+	e, ok := err.(*Error)
+	if !ok || e.msg != "storage" || e.Fields()["layer"] != "storage" {
+		t.Fatalf("Wrap() = %v, want a link annotated with the layer", err)
+	}
+	if e.cause == error(inner) {
+		t.Errorf("expected the error sanitized per the policy, not wrapped as is")
+	}
+	if again := storage.Wrap(err); again != err {
+		t.Errorf("expected an error already across the boundary to be left alone")
+	}
+
+	unregistered := Boundary("example.com/app/cache").Wrap(inner)
+	if !Is(unregistered, inner) || allFields(unregistered)["layer"] != "example.com/app/cache" {
+		t.Errorf("Wrap() = %v, want an unregistered package to be its own layer", unregistered)
+	}
+
+	RegisterBoundary("example.com/app/api", BoundaryPolicy{Layer: "api", MaxDepth: 2})
+	deep := Wrap(Wrap(Wrap(inner, "querying"), "loading"), "handling")
+	if n := len(Boundary("example.com/app/api").Wrap(deep).(*Error).Causes()); n != 3 {
+		t.Errorf("Wrap() kept %d links, want the layer's and MaxDepth of the rest", n)
+	}
+}
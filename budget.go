@@ -0,0 +1,30 @@
+package eros
+
+import (
+	"os"
+	"sync"
+	"sync/atomic"
+)
+
+// FatalBudget - registers an observer counting the Fatal errors recovered (or dropped
+// unhandled), and calls onExhausted once the n-th arrives. A process recovering from
+// fatal errors over and over is crash looping internally; better it terminates or
+// signals for a restart than limp on. A nil onExhausted exits the process with status
+// 1. Returns a func removing the budget again
+func FatalBudget(n int, onExhausted func()) (remove func()) {
+	if onExhausted == nil {
+		onExhausted = func() { os.Exit(1) }
+	}
+	var (
+		spent int64
+		once  sync.Once
+	)
+	return Observe(func(ev Event, err *Error) {
+		if ev != Recovered && ev != Unhandled || err.Severity() != Fatal {
+			return
+		}
+		if atomic.AddInt64(&spent, 1) >= int64(n) {
+			once.Do(onExhausted)
+		}
+	})
+}
@@ -0,0 +1,41 @@
+package eros
+
+import (
+	"testing"
+)
+
+func TestFatalBudget(t *testing.T) {
+	exhausted := 0
+	remove := FatalBudget(2, func() { exhausted++ })
+	defer remove()
+
+	raise := func(err *Error) {
+		defer ErrorHandler(func(*Error) {})()
+		Check(err)
+	}
+	raise(New("ordinary"))
+	raise(New("warning").WithSeverity(Warning))
+	raise(New("fatal").WithSeverity(Fatal))
+	if exhausted != 0 {
+		t.Fatalf("expected the budget to hold after one fatal error")
+	}
+	raise(Wrap(New("fatal").WithSeverity(Fatal), "again"))
+	raise(New("fatal").WithSeverity(Fatal))
+	if exhausted != 1 {
+		t.Errorf("expected onExhausted once the budget was spent, called %d times", exhausted)
+	}
+}
+
+func TestFatalBudgetIgnoresOtherEvents(t *testing.T) {
+	exhausted := 0
+	remove := FatalBudget(1, func() { exhausted++ })
+	defer remove()
+
+	fatal := New("fatal").WithSeverity(Fatal)
+	for _, ev := range []Event{Raised, Misused, Warned, Reported} {
+		notify(ev, fatal)
+	}
+	if exhausted != 0 {
+		t.Errorf("expected only Recovered and Unhandled to spend the budget, called %d times", exhausted)
+	}
+}
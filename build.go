@@ -0,0 +1,70 @@
+package eros
+
+// Opt - an option of Build and Wrap, so a rich error is made in one call rather than a
+// long chain of With methods
+//
+//	err := eros.Build("quota exhausted",
+//		eros.WithCodeOpt(eros.Unavailable),
+//		eros.WithFieldOpt("tenant", id),
+//		eros.WithSeverityOpt(eros.Warning))
+type Opt func(b *building)
+
+// building - the error Opts are applied to, and what's left for Build or Wrap to do
+type building struct {
+	e     *Error
+	stack bool
+}
+
+// WithCodeOpt - the error is coded code, see WithCode
+func WithCodeOpt(code string) Opt {
+	return func(b *building) {
+		b.e.WithCode(code)
+	}
+}
+
+// WithFieldOpt - the error has the field key, see WithField
+func WithFieldOpt(key string, value interface{}) Opt {
+	return func(b *building) {
+		b.e.WithField(key, value)
+	}
+}
+
+// WithFieldsOpt - the error has fields, see WithFields
+func WithFieldsOpt(fields map[string]interface{}) Opt {
+	return func(b *building) {
+		b.e.WithFields(fields)
+	}
+}
+
+// WithSeverityOpt - the error is of severity s, see WithSeverity
+func WithSeverityOpt(s Severity) Opt {
+	return func(b *building) {
+		b.e.WithSeverity(s)
+	}
+}
+
+// WithStackOpt - the error has the stack it was made at even with stacks off (see
+// CaptureStacks), unless the chain already has one, as WithStack
+func WithStackOpt() Opt {
+	return func(b *building) {
+		b.stack = true
+	}
+}
+
+// Build - New, with opts applied
+func Build(msg string, opts ...Opt) *Error {
+	e := newError(msg, 1)
+	if apply(e, opts) && !hasStack(e) {
+		e.stack = callers(1)
+	}
+	return e
+}
+
+// apply - applies opts to e, whether one asked for a stack
+func apply(e *Error, opts []Opt) bool {
+	b := building{e: e}
+	for _, opt := range opts {
+		opt(&b)
+	}
+	return b.stack
+}
@@ -0,0 +1,33 @@
+package eros
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestBuild(t *testing.T) {
+	err := Build("quota exhausted",
+		WithCodeOpt(Unavailable),
+		WithFieldsOpt(map[string]interface{}{"tenant": 7}),
+		WithFieldOpt("plan", "free"),
+		WithSeverityOpt(Warning),
+		WithStackOpt())
+	if Code(err) != Unavailable || err.Severity() != Warning {
+		t.Errorf("Build() = %v, want the code and severity applied", err)
+	}
+	if f := err.Fields(); f["tenant"] != 7 || f["plan"] != "free" {
+		t.Errorf("Build() has fields %v, want those given", f)
+	}
+	if frames := err.Frames(); len(frames) == 0 || !strings.Contains(frames[0].Function, "TestBuild") {
+		t.Errorf("Build() has stack %v, want it made where Build was called", frames)
+	}
+
+	wrapped := Wrap(io.EOF, "reading header", WithCodeOpt(InvalidArgument))
+	if Code(wrapped) != InvalidArgument || !Is(wrapped, io.EOF) {
+		t.Errorf("Wrap() = %v, want the option applied to the new link", wrapped)
+	}
+	if len(Wrap(io.EOF, "plain").Frames()) != 0 {
+		t.Errorf("expected no stack without stacks on or WithStackOpt")
+	}
+}
@@ -0,0 +1,88 @@
+package eros
+
+import (
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// CountChecks - when on, every Check/CheckVal call site which fails is counted, see
+// CheckStats. Off by default; it costs a runtime.Callers and a map lookup per failure
+func CountChecks(on bool) Setting {
+	return func(c *config) {
+		c.checkStats = on
+	}
+}
+
+// CheckStat - how often one Check/CheckVal call site failed
+type CheckStat struct {
+	Function string
+	File     string
+	Line     int
+	Failures uint64
+}
+
+// checkSite - the failure count of one call site, resolved to a frame on first use
+type checkSite struct {
+	failures uint64
+	once     sync.Once
+	frame    runtime.Frame
+}
+
+// checkSites - the call sites which failed, by program counter
+var checkSites sync.Map
+
+// countCheck - counts a failure of the call site skip frames above countCheck's caller
+func countCheck(skip int) {
+	if !conf().checkStats {
+		return
+	}
+	var pc [1]uintptr
+	if runtime.Callers(skip+2, pc[:]) == 0 {
+		return
+	}
+	site, ok := checkSites.Load(pc[0])
+	if !ok {
+		site, _ = checkSites.LoadOrStore(pc[0], &checkSite{})
+	}
+	atomic.AddUint64(&site.(*checkSite).failures, 1)
+}
+
+// CheckStats - the Check/CheckVal call sites which failed while CountChecks was on,
+// the flakiest first
+func CheckStats() []CheckStat {
+	var stats []CheckStat
+	checkSites.Range(func(k, v interface{}) bool {
+		site := v.(*checkSite)
+		site.once.Do(func() {
+			site.frame, _ = runtime.CallersFrames([]uintptr{k.(uintptr)}).Next()
+		})
+		stats = append(stats, CheckStat{
+			Function: site.frame.Function,
+			File:     site.frame.File,
+			Line:     site.frame.Line,
+			Failures: atomic.LoadUint64(&site.failures),
+		})
+		return true
+	})
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Failures != stats[j].Failures {
+			return stats[i].Failures > stats[j].Failures
+		}
+		if stats[i].File != stats[j].File {
+			return stats[i].File < stats[j].File
+		}
+		return stats[i].Line < stats[j].Line
+	})
+	return stats
+}
+
+// ResetCheckStats - forgets every call site counted so far, e.g. between the windows
+// CheckStats is exported for
+func ResetCheckStats() {
+	checkSites.Range(func(k, _ interface{}) bool {
+		checkSites.Delete(k)
+		return true
+	})
+}
@@ -0,0 +1,65 @@
+package eros
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+var errFlakySite = errors.New("flaky site")
+
+func flakyCheck(err error) {
+	defer ErrorHandler(func(*Error) {})()
+	Check(err)
+}
+
+func flakyCheckVal(err error) {
+	defer ErrorHandler(func(*Error) {})()
+	CheckVal(1, err)
+}
+
+func TestCheckStats(t *testing.T) {
+	ResetCheckStats()
+	flakyCheck(errFlakySite)
+	Configure(CountChecks(true))
+	defer Configure(CountChecks(false))
+	defer ResetCheckStats()
+
+	flakyCheck(errFlakySite)
+	flakyCheck(errFlakySite)
+	flakyCheck(nil)
+	flakyCheckVal(errFlakySite)
+
+	failures := map[string]uint64{}
+	var order []string
+	for _, s := range CheckStats() {
+		switch s.Function {
+		case "github.com/dawenga/eros.flakyCheck", "github.com/dawenga/eros.flakyCheckVal":
+			if s.Line == 0 || s.File == "" {
+				t.Errorf("CheckStats() %s unresolved, %s:%d", s.Function, s.File, s.Line)
+			}
+			failures[s.Function] = s.Failures
+			order = append(order, s.Function)
+		}
+	}
+	if failures["github.com/dawenga/eros.flakyCheck"] != 2 {
+		t.Errorf("CheckStats() flakyCheck failures = %d, want 2", failures["github.com/dawenga/eros.flakyCheck"])
+	}
+	if failures["github.com/dawenga/eros.flakyCheckVal"] != 1 {
+		t.Errorf("CheckStats() flakyCheckVal failures = %d, want 1", failures["github.com/dawenga/eros.flakyCheckVal"])
+	}
+	if len(order) != 2 || order[0] != "github.com/dawenga/eros.flakyCheck" {
+		t.Errorf("CheckStats() order = %v, want the flakiest first", order)
+	}
+}
+
+func TestResetCheckStats(t *testing.T) {
+	Configure(CountChecks(true))
+	defer Configure(CountChecks(false))
+
+	flakyCheck(errFlakySite)
+	ResetCheckStats()
+	if stats := CheckStats(); len(stats) != 0 {
+		t.Errorf("CheckStats() = %v after ResetCheckStats, want nothing", stats)
+	}
+}
@@ -0,0 +1,60 @@
+package eros
+
+import (
+	"io"
+	"strings"
+)
+
+// CheckClose - closes c, raising its failure as Check would. Meant to be deferred
+// directly, in place of a defer c.Close() which drops the error;
+//
+//	defer eros.CheckClose(f, "closing the export")
+//
+// When the function is already failing, i.e. a Check raised (or left its error
+// pending in panic free mode, see Pending), the close failure is chained into that
+// error with WithCause rather than replacing it. Any other panic carries on untouched
+func CheckClose(c io.Closer, mesgs ...string) {
+	r := recover()
+	cerr := c.Close()
+	if r == nil {
+		if cerr == nil {
+			return
+		}
+		if p := Pending(); p != nil {
+			p.WithCause(closeFailed(cerr, mesgs))
+			return
+		}
+		countCheck(1)
+		raise(closeFailed(cerr, mesgs))
+		return
+	}
+	if p, ok := r.(checkPanic); ok && cerr != nil {
+		p.err.WithCause(closeFailed(cerr, mesgs))
+	}
+	panic(r)
+}
+
+// CloseInto - closes c, for the return flow; a failure is stored in *errp, or if
+// that's already set, chained into it with WithCause rather than dropping either
+//
+//	defer eros.CloseInto(f, &err)
+func CloseInto(c io.Closer, errp *error, mesgs ...string) {
+	cerr := c.Close()
+	if cerr == nil {
+		return
+	}
+	if *errp == nil {
+		*errp = closeFailed(cerr, mesgs)
+		return
+	}
+	*errp = CastOrWrap(*errp).WithCause(closeFailed(cerr, mesgs))
+}
+
+// closeFailed - cerr, the failure of a Close, wrapped
+func closeFailed(cerr error, mesgs []string) *Error {
+	msg := "failed to close"
+	if len(mesgs) > 0 {
+		msg = strings.Join(mesgs, ",")
+	}
+	return Wrap(cerr, msg)
+}
@@ -0,0 +1,87 @@
+package eros
+
+import (
+	"io"
+	"testing"
+)
+
+// closer - an io.Closer failing with err
+type closer struct {
+	err    error
+	closed bool
+}
+
+func (c *closer) Close() error {
+	c.closed = true
+	return c.err
+}
+
+var errDiskFull = New("disk full")
+
+func TestCheckClose(t *testing.T) {
+	run := func(c io.Closer, fail error) (got *Error) {
+		defer ErrorHandler(func(err *Error) { got = err })()
+		func() {
+			defer CheckClose(c, "closing export")
+			Check(fail)
+		}()
+		return nil
+	}
+
+	c := &closer{}
+	if got := run(c, nil); got != nil || !c.closed {
+		t.Errorf("CheckClose() raised %v, want nothing from a clean close", got)
+	}
+	got := run(&closer{err: errDiskFull}, nil)
+	if !Is(got, errDiskFull) || got.msg != "closing export" {
+		t.Errorf("CheckClose() raised %v, want the close failure", got)
+	}
+	got = run(&closer{err: errDiskFull}, io.ErrShortWrite)
+	if !Is(got, io.ErrShortWrite) || !Is(got, errDiskFull) {
+		t.Errorf("CheckClose() raised %v, want both the failure and the close failure", got)
+	}
+
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Errorf("expected a foreign panic to carry on, got %v", r)
+		}
+	}()
+	func() {
+		defer CheckClose(&closer{err: errDiskFull})
+		panic("boom")
+	}()
+}
+
+func TestCheckClosePanicFree(t *testing.T) {
+	Configure(PanicFree(true))
+	defer Configure(PanicFree(false))
+
+	var got *Error
+	func() {
+		defer ErrorHandler(func(err *Error) { got = err })()
+		defer CheckClose(&closer{err: errDiskFull}, "closing export")
+		Check(io.ErrShortWrite)
+	}()
+	if !Is(got, io.ErrShortWrite) || !Is(got, errDiskFull) {
+		t.Errorf("CheckClose() raised %v, want both the failure and the close failure", got)
+	}
+}
+
+func TestCloseInto(t *testing.T) {
+	write := func(c io.Closer, fail error) (err error) {
+		defer CloseInto(c, &err)
+		return fail
+	}
+	if err := write(&closer{}, nil); err != nil {
+		t.Errorf("CloseInto() = %v, want nil", err)
+	}
+	if err := write(&closer{err: errDiskFull}, nil); !Is(err, errDiskFull) {
+		t.Errorf("CloseInto() = %v, want the close failure", err)
+	}
+	if err := write(&closer{err: errDiskFull}, io.ErrShortWrite); !Is(err, io.ErrShortWrite) || !Is(err, errDiskFull) {
+		t.Errorf("CloseInto() = %v, want both failures chained", err)
+	}
+	if err := write(&closer{}, io.ErrShortWrite); err != io.ErrShortWrite {
+		t.Errorf("CloseInto() = %v, want the failure untouched by a clean close", err)
+	}
+}
@@ -0,0 +1,135 @@
+package eros
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sync"
+)
+
+// WithCode - classifies this link in the chain with code, e.g. for API responses or
+// metrics. This is nil safe
+func (e *Error) WithCode(code string) *Error {
+	if e != nil {
+		e.code = code
+	}
+	return e
+}
+
+// Code - the code of the chain, see Code
+func (e *Error) Code() string {
+	if e == nil {
+		return ""
+	}
+	return Code(e)
+}
+
+// AutoCode - the code of the chain if it has one, otherwise a short code derived from
+// the message this link was made with; its format for Newf and Wrapf, so the values
+// formatted into it don't matter. Stable across releases as long as the message
+// is, so even ad hoc errors can be tracked on a dashboard
+func (e *Error) AutoCode() string {
+	if e == nil {
+		return ""
+	}
+	if code := Code(e); code != "" {
+		return code
+	}
+	text := e.format
+	if text == "" {
+		text = e.text()
+	}
+	h := fnv.New32a()
+	h.Write([]byte(text))
+	return fmt.Sprintf("E%08X", h.Sum32())
+}
+
+// Code - the outermost code found in err's chain, the classification closest to the
+// caller wins. Links that aren't eros errors may take part by implementing
+// Code() string. Empty when nothing in the chain has a code
+func Code(err error) string {
+	code := ""
+	walk(err, func(l error) bool {
+		code = linkCode(l)
+		return code == ""
+	})
+	return code
+}
+
+// linkCode - the code of this one link in a chain
+func linkCode(err error) string {
+	if e := link(err); e != nil {
+		return e.code
+	}
+	if c, ok := err.(interface{ Code() string }); ok {
+		return c.Code()
+	}
+	return ""
+}
+
+// CodeInfo - what a code stands for, declared once with RegisterCode rather than at
+// every site making an error with it
+type CodeInfo struct {
+	// Message - the message of errors made by NewCode, WrapCode and CheckCode
+	Message string
+	// Fault - whose fault errors with the code are, see FaultOf
+	Fault Fault
+	// Severity - the severity of a chain with the code and no explicit severity
+	Severity Severity
+	// HTTPStatus - the status it maps to, see HTTPStatus. 0 goes by Fault
+	HTTPStatus int
+	// GRPCCode - the gRPC status code it maps to, see GRPCCode. 0 is Unknown
+	GRPCCode int
+	// Public - whether the code may be shown to anyone, see Sanitize
+	Public bool
+}
+
+var (
+	codesMu sync.RWMutex
+	codes   = map[string]CodeInfo{}
+)
+
+// RegisterCode - declares code, replacing whatever was declared for it before
+func RegisterCode(code string, info CodeInfo) {
+	codesMu.Lock()
+	defer codesMu.Unlock()
+	codes[code] = info
+}
+
+// LookupCode - what was declared for code, if anything
+func LookupCode(code string) (CodeInfo, bool) {
+	if code == "" {
+		return CodeInfo{}, false
+	}
+	codesMu.RLock()
+	defer codesMu.RUnlock()
+	info, ok := codes[code]
+	return info, ok
+}
+
+// codeMessage - the message declared for code, the code itself if there is none
+func codeMessage(code string) string {
+	if info, ok := LookupCode(code); ok && info.Message != "" {
+		return info.Message
+	}
+	return code
+}
+
+// NewCode - New, with the message declared for code
+func NewCode(code string) *Error {
+	return newError(codeMessage(code), 1).WithCode(code)
+}
+
+// WrapCode - Wrap, with the message declared for code
+func WrapCode(err error, code string) *Error {
+	e := wrap(err, codeMessage(code), 1).WithCode(code)
+	countError(e)
+	return e
+}
+
+// CheckCode - Check, raising err wrapped as WrapCode would
+func CheckCode(err error, code string) {
+	if err != nil {
+		countCheck(1)
+		raise(wrap(err, codeMessage(code), 1).WithCode(code))
+	}
+}
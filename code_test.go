@@ -0,0 +1,87 @@
+package eros
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestAutoCode(t *testing.T) {
+	lookup := func(id int) *Error { return Newf("no user %d", id) }
+	tests := []struct {
+		name string
+		a, b *Error
+		same bool
+	}{
+		{"Test the values formatted in don't matter", lookup(1), lookup(2), true},
+		{"Test Wrapf by its format", Wrapf(io.EOF, "reading %s", "a"), Wrapf(io.EOF, "reading %s", "b"), true},
+		{"Test a different message", New("no user"), New("no group"), false},
+		{"Test New by its message", New("no user"), New("no user"), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := tt.a.AutoCode(), tt.b.AutoCode()
+			if !strings.HasPrefix(a, "E") || len(a) != 9 {
+				t.Errorf("AutoCode() = %q, want a short derived code", a)
+			}
+			if (a == b) != tt.same {
+				t.Errorf("AutoCode() = %q and %q, want same %v", a, b, tt.same)
+			}
+		})
+	}
+	if got := Wrap(New("x").WithCode(NotFound), "y").AutoCode(); got != NotFound {
+		t.Errorf("AutoCode() = %q, want the explicit code", got)
+	}
+}
+
+func TestRegisterCode(t *testing.T) {
+	RegisterCode("TEST_QUOTA", CodeInfo{Message: "over quota", Fault: ClientFault, Severity: Warning, HTTPStatus: 429})
+	ClassifyCode("TEST_QUOTA", ServerFault)
+	info, ok := LookupCode("TEST_QUOTA")
+	if !ok || info.Message != "over quota" || info.Fault != ServerFault {
+		t.Errorf("LookupCode() = %+v, want the declaration with the fault reclassified", info)
+	}
+	if _, ok := LookupCode("TEST_UNDECLARED"); ok {
+		t.Errorf("LookupCode() found an undeclared code")
+	}
+
+	tests := []struct {
+		name    string
+		err     *Error
+		wantMsg string
+	}{
+		{"Test NewCode", NewCode("TEST_QUOTA"), "over quota"},
+		{"Test WrapCode", WrapCode(io.EOF, "TEST_QUOTA"), "over quota"},
+		{"Test an undeclared code is its own message", NewCode("TEST_UNDECLARED"), "TEST_UNDECLARED"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err.msg != tt.wantMsg {
+				t.Errorf("message = %q, want %q", tt.err.msg, tt.wantMsg)
+			}
+			if tt.err.code == "" {
+				t.Errorf("expected the link to be coded")
+			}
+		})
+	}
+	err := WrapCode(io.EOF, "TEST_QUOTA")
+	if HTTPStatus(err) != 429 || SeverityOf(err) != Warning || FaultOf(err) != ServerFault {
+		t.Errorf("expected the declaration to drive the mappings of %v", err)
+	}
+
+	var got *Error
+	func() {
+		defer ErrorHandler(func(err *Error) { got = err })()
+		CheckCode(io.EOF, "TEST_QUOTA")
+	}()
+	if got == nil || Code(got) != "TEST_QUOTA" || !Is(got, io.EOF) {
+		t.Errorf("CheckCode() raised %v", got)
+	}
+}
+
+func TestCodeBelowWithCause(t *testing.T) {
+	err := Wrap(New("x").WithCode("A"), "outer").WithCause(New("other"))
+	if got := Code(err); got != "A" {
+		t.Errorf("Code() = %q, want the code of the wrapped cause", got)
+	}
+}
@@ -0,0 +1,97 @@
+package eros
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Collector - accumulates the errors of code that fails through, carrying on past
+// each failure and reporting them all at the end, rather than chaining them onto a
+// captured *Error by hand. The zero value is ready to use, but not by several
+// goroutines at once, see NewCollector
+//
+//	var c eros.Collector
+//	defer eros.ErrorHandler(c.Handler())()
+//	cfg := load().Handle(c.Handler())
+//	...
+//	return c.Err()
+type Collector struct {
+	mu   *sync.Mutex
+	errs []error
+}
+
+// NewCollector - a Collector, safe for use by several goroutines at once if concurrent
+func NewCollector(concurrent bool) *Collector {
+	c := &Collector{}
+	if concurrent {
+		c.mu = &sync.Mutex{}
+	}
+	return c
+}
+
+// Add - collects err, nil is ignored
+func (c *Collector) Add(err error) {
+	if err == nil {
+		return
+	}
+	mark(err)
+	if c.mu != nil {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+	c.errs = append(c.errs, err)
+}
+
+// Addf - collects a new error, see Newf
+func (c *Collector) Addf(format string, args ...interface{}) {
+	e := newError(fmt.Sprintf(format, args...), 1)
+	e.format = format
+	c.Add(e)
+}
+
+// Handler - a Handler collecting what it's handed, for Result.Handle, ErrorHandler and
+// the like
+func (c *Collector) Handler() Handler {
+	return func(err *Error) {
+		c.Add(err)
+	}
+}
+
+// Len - how many errors were collected
+func (c *Collector) Len() int {
+	if c.mu != nil {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+	return len(c.errs)
+}
+
+// Err - what was collected chained (see WithCause), the most recent first. Every
+// error collected is in it, the same failure collected twice included, so it holds
+// Len links or more. nil if nothing was
+func (c *Collector) Err() *Error {
+	if c.mu != nil {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+	return chain(c.errs)
+}
+
+// chain - errs chained as WithCause would, the last of them first. nil if there are
+// none. WithCause skips a cause the chain already holds, here every one is kept
+func chain(errs []error) *Error {
+	var res *Error
+	for _, err := range errs {
+		// a copy, the error collected is left alone
+		e := CastOrWrap(err)
+		switch {
+		case res == nil:
+		case e.next == nil:
+			e.next, e.count = res, res.count+1
+		default:
+			e.WithCause(res)
+		}
+		res = e
+	}
+	return res
+}
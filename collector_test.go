@@ -0,0 +1,73 @@
+package eros
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestCollector(t *testing.T) {
+	var c Collector
+	if c.Err() != nil {
+		t.Errorf("Err() = %v, want nil with nothing collected", c.Err())
+	}
+	func() {
+		defer ErrorHandler(c.Handler())()
+		Cast(0, io.EOF).Handle(c.Handler())
+		c.Add(nil)
+		c.Addf("row %d is empty", 3)
+		Check(errStepFailed)
+	}()
+	if c.Len() != 3 {
+		t.Fatalf("Len() = %d, want every failure collected", c.Len())
+	}
+	err := c.Err()
+	for _, target := range []error{io.EOF, errStepFailed} {
+		if !Is(err, target) {
+			t.Errorf("Err() = %v, want it to hold %v", err, target)
+		}
+	}
+
+	var ordered Collector
+	for _, msg := range []string{"a", "b", "c"} {
+		ordered.Addf(msg)
+	}
+	var msgs []string
+	walk(ordered.Err(), func(l error) bool {
+		msgs = append(msgs, message(l))
+		return true
+	})
+	if got := strings.Join(msgs, ","); got != "c,b,a" {
+		t.Errorf("Err() chained %s, want the most recent first, c,b,a", got)
+	}
+
+	var dups Collector
+	dups.Add(errors.New("timeout"))
+	dups.Add(errors.New("timeout"))
+	n := 0
+	walk(dups.Err(), func(l error) bool {
+		if message(l) == "timeout" {
+			n++
+		}
+		return true
+	})
+	if n != 2 {
+		t.Errorf("Err() holds %d timeouts, want both collected", n)
+	}
+
+	safe := NewCollector(true)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			safe.Addf("worker %d failed", i)
+		}(i)
+	}
+	wg.Wait()
+	if safe.Len() != 10 {
+		t.Errorf("Len() = %d, want every worker's failure collected", safe.Len())
+	}
+}
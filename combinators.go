@@ -0,0 +1,100 @@
+package eros
+
+import "context"
+
+// Combinators let a pipeline of fallible steps be composed on Results, deciding what
+// to do about the error once at the end rather than checking after every step.
+
+// Map - r with fn applied to its value, or r's error untouched
+func Map[T, U any](r Result[T], fn func(T) U) Result[U] {
+	if r.Error != nil {
+		return Result[U]{Error: r.Error}
+	}
+	return Result[U]{Value: fn(r.Value)}
+}
+
+// AndThen - the Result of fn on r's value, or r's error untouched. Map, for a fn which
+// may fail itself
+func AndThen[T, U any](r Result[T], fn func(T) Result[U]) Result[U] {
+	if r.Error != nil {
+		return Result[U]{Error: r.Error}
+	}
+	return fn(r.Value)
+}
+
+// MapErr - r with fn applied to its error, e.g. to wrap or translate it. A nil from fn
+// makes r ok. An ok r is returned as is
+func (r Result[T]) MapErr(fn func(err error) error) Result[T] {
+	if r.Error != nil {
+		r.Error = fn(r.Error)
+	}
+	return r
+}
+
+// OrElse - the Result of fn on r's error, a fallback, or r itself if it's ok
+func (r Result[T]) OrElse(fn func(err error) Result[T]) Result[T] {
+	if r.Error != nil {
+		return fn(r.Error)
+	}
+	return r
+}
+
+// UnwrapOr - r's value, or def if r failed
+func (r Result[T]) UnwrapOr(def T) T {
+	if r.Error != nil {
+		return def
+	}
+	return r.Value
+}
+
+// IsOk - whether r succeeded
+func (r Result[T]) IsOk() bool {
+	return r.Error == nil
+}
+
+// IsErr - whether r failed
+func (r Result[T]) IsErr() bool {
+	return r.Error != nil
+}
+
+// OrCtx - r, unless it's ok but ctx is done, in which case its value was computed
+// after there was anyone left to use it and it's replaced by ctx's error, coded
+// DeadlineExceeded or Canceled as the case may be. A failed r is returned as is
+func (r Result[T]) OrCtx(ctx context.Context) Result[T] {
+	if r.Error != nil {
+		return r
+	}
+	if err := ctx.Err(); err != nil {
+		return Result[T]{Error: ctxDone(wrap(err, "context done before the result was used", 1), err)}
+	}
+	return r
+}
+
+// Lift - fn, returning a Result rather than a (value, error) tuple, so a function of
+// the standard library or a third party can be converted once and composed with the
+// combinators from then on
+//
+//	parse := eros.Lift(strconv.Atoi)
+//	n := eros.AndThen(read(), parse).Check()
+func Lift[A, B any](fn func(A) (B, error)) func(A) Result[B] {
+	return func(a A) Result[B] {
+		b, err := fn(a)
+		return Result[B]{Value: b, Error: err}
+	}
+}
+
+// Lift0 - Lift, for a fn taking no arguments
+func Lift0[B any](fn func() (B, error)) func() Result[B] {
+	return func() Result[B] {
+		b, err := fn()
+		return Result[B]{Value: b, Error: err}
+	}
+}
+
+// Lift2 - Lift, for a fn taking two arguments
+func Lift2[A1, A2, B any](fn func(A1, A2) (B, error)) func(A1, A2) Result[B] {
+	return func(a1 A1, a2 A2) Result[B] {
+		b, err := fn(a1, a2)
+		return Result[B]{Value: b, Error: err}
+	}
+}
@@ -0,0 +1,110 @@
+package eros
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+var errParse = errors.New("not a number")
+
+func parse(s string) Result[int] {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return Result[int]{Error: errParse}
+	}
+	return Result[int]{Value: n}
+}
+
+func TestCombinators(t *testing.T) {
+	half := func(n int) Result[int] {
+		if n%2 != 0 {
+			return Result[int]{Error: New("odd")}
+		}
+		return Result[int]{Value: n / 2}
+	}
+	tests := []struct {
+		name    string
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"Test every step succeeds", "42", "21", false},
+		{"Test the first step fails", "x", "", true},
+		{"Test a later step fails", "7", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Map(AndThen(parse(tt.in), half), strconv.Itoa)
+			if got.IsErr() != tt.wantErr || got.IsOk() == tt.wantErr {
+				t.Fatalf("pipeline error = %v, wantErr %v", got.Error, tt.wantErr)
+			}
+			if got.Value != tt.want {
+				t.Errorf("pipeline = %q, want %q", got.Value, tt.want)
+			}
+		})
+	}
+
+	wrapped := parse("x").MapErr(func(err error) error { return Wrap(err, "parsing") })
+	if !Is(wrapped.Error, errParse) || wrapped.Error.(*Error).msg != "parsing" {
+		t.Errorf("MapErr() = %v, want the error wrapped", wrapped.Error)
+	}
+	if cleared := parse("x").MapErr(func(error) error { return nil }); !cleared.IsOk() {
+		t.Errorf("MapErr() returning nil should make the result ok")
+	}
+	if got := parse("x").OrElse(func(error) Result[int] { return parse("3") }); got.Value != 3 || got.IsErr() {
+		t.Errorf("OrElse() = %v, want the fallback", got)
+	}
+	if got := parse("5").OrElse(func(error) Result[int] { return parse("3") }); got.Value != 5 {
+		t.Errorf("OrElse() = %v, want the ok result untouched", got)
+	}
+	if parse("x").UnwrapOr(-1) != -1 || parse("5").UnwrapOr(-1) != 5 {
+		t.Errorf("UnwrapOr() didn't fall back only on failure")
+	}
+}
+
+func TestOrCtx(t *testing.T) {
+	ok := Result[int]{Value: 1}
+	if got := ok.OrCtx(context.Background()); got.IsErr() || got.Value != 1 {
+		t.Errorf("OrCtx() = %v, want the ok result untouched while ctx is live", got)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if got := ok.OrCtx(ctx); !Is(got.Error, context.Canceled) || Code(got.Error) != Canceled {
+		t.Errorf("OrCtx() = %v, want a coded cancellation", got.Error)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 0)
+	defer cancel()
+	<-ctx.Done()
+	if got := ok.OrCtx(ctx); Code(got.Error) != DeadlineExceeded || HTTPStatus(got.Error) != 504 {
+		t.Errorf("OrCtx() = %v, want the deadline coded as such", got.Error)
+	}
+
+	failed := Result[int]{Error: errParse}
+	if got := failed.OrCtx(ctx); got.Error != errParse {
+		t.Errorf("OrCtx() = %v, want a failed result untouched", got.Error)
+	}
+}
+
+func TestLift(t *testing.T) {
+	atoi := Lift(strconv.Atoi)
+	if got := AndThen(Result[string]{Value: "5"}, atoi); got.IsErr() || got.Value != 5 {
+		t.Errorf("Lift() = %v, want the value", got)
+	}
+	if got := atoi("x"); got.IsOk() {
+		t.Errorf("Lift() = %v, want the error", got)
+	}
+	if got := Lift0(func() (int, error) { return 0, errParse })(); got.Error != errParse {
+		t.Errorf("Lift0() = %v, want the error", got)
+	}
+	parseBase := func(s string, base int) (int64, error) {
+		return strconv.ParseInt(s, base, 64)
+	}
+	if got := Lift2(parseBase)("ff", 16); got.IsErr() || got.Value != 255 {
+		t.Errorf("Lift2() = %v, want the value", got)
+	}
+}
@@ -0,0 +1,92 @@
+package eros
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Compact64 - the whole chain (as WriteJSON renders it) gzipped and base64 encoded
+// into a single url safe token, for support tickets and size limited log fields.
+// FromCompact64 turns it back into a chain
+func Compact64(err error) string {
+	if err == nil {
+		return ""
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if werr := WriteJSON(zw, err); werr != nil {
+		return ""
+	}
+	if werr := zw.Close(); werr != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(buf.Bytes())
+}
+
+// FromCompact64 - rebuilds the chain encoded by Compact64, with each link's message,
+// code, fields, id, time and severity as they were. Links omitted by the render limit
+// are stood in for by a single link saying how many there were. Nothing new failed,
+// so nothing is counted, see CountErrors
+func FromCompact64(s string) (*Error, error) {
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, Wrap(err, "failed to decode compact error")
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		return nil, Wrap(err, "failed to decompress compact error")
+	}
+	defer zr.Close()
+	raw, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, Wrap(err, "failed to decompress compact error")
+	}
+	var chain struct {
+		Links   []jsonLink `json:"links"`
+		Omitted int        `json:"omitted"`
+	}
+	if err := json.Unmarshal(raw, &chain); err != nil {
+		return nil, Wrap(err, "failed to parse compact error")
+	}
+	if len(chain.Links) == 0 {
+		return nil, New("compact error has no links")
+	}
+	return fromLinks(chain.Links, chain.Omitted), nil
+}
+
+// fromLinks - a chain rebuilt from its links, outermost first. When omitted links
+// were dropped before the root, a link counting them takes their place
+func fromLinks(links []jsonLink, omitted int) *Error {
+	last := len(links) - 1
+	res := fromLink(links[last], nil)
+	if omitted > 0 {
+		res = &Error{msg: fmt.Sprintf("%d links omitted", omitted), cause: res, count: 1}
+	}
+	for i := last - 1; i >= 0; i-- {
+		res = fromLink(links[i], res)
+	}
+	return res
+}
+
+// fromLink - the link l decodes to, wrapping cause if it isn't the root
+func fromLink(l jsonLink, cause *Error) *Error {
+	e := &Error{
+		msg:      l.Message,
+		code:     l.Code,
+		fields:   l.Fields,
+		id:       l.ID,
+		severity: Severity(l.Severity),
+	}
+	if l.Time != nil {
+		e.at = *l.Time
+	}
+	if cause != nil {
+		e.cause, e.count = cause, 1
+	}
+	return e
+}
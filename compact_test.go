@@ -0,0 +1,58 @@
+package eros
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCompact64(t *testing.T) {
+	err := Wrap(New("no such user").WithCode("NOT_FOUND").WithField("user", "bob"), "failed to load profile").
+		WithField("request", "r-1")
+	token := Compact64(err)
+	if token == "" {
+		t.Fatal("Compact64() returned an empty token")
+	}
+	got, derr := FromCompact64(token)
+	if derr != nil {
+		t.Fatal(derr)
+	}
+	var want, have bytes.Buffer
+	WriteChain(&want, err)
+	WriteChain(&have, got)
+	if want.String() != have.String() {
+		t.Errorf("FromCompact64() = %q, want %q", have.String(), want.String())
+	}
+	if Code(got) != "NOT_FOUND" {
+		t.Errorf("FromCompact64() lost the code")
+	}
+
+	root, outer := link(err.Unwrap()), link(got)
+	if outer.id != err.id || !outer.at.Equal(err.at) {
+		t.Errorf("FromCompact64() = %s at %v, want the id and time kept", outer.id, outer.at)
+	}
+	if inner := link(got.Unwrap()); inner.id != root.id {
+		t.Errorf("FromCompact64() root id = %s, want %s", inner.id, root.id)
+	}
+
+	if _, derr := FromCompact64("not a token!"); derr == nil {
+		t.Errorf("expected an error decoding garbage")
+	}
+}
+
+func TestFromCompact64Uncounted(t *testing.T) {
+	token := Compact64(Wrap(New("disk full").WithSeverity(Fatal), "writing segment"))
+	c := &Counters{}
+	Configure(CountErrors(c))
+	defer Configure(CountErrors(nil))
+
+	got, err := FromCompact64(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Severity() != Fatal {
+		t.Errorf("FromCompact64() severity = %v, want it kept", got.Severity())
+	}
+	if counted := c.Snapshot(); len(counted) != 0 {
+		t.Errorf("FromCompact64() counted %v, want nothing", counted)
+	}
+}
@@ -0,0 +1,71 @@
+package eros
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// config - the package wide knobs. A config in effect is never changed, Configure
+// swaps in a changed copy, so it can be read without locking on hot paths
+type config struct {
+	goroutines    bool
+	unhandled     bool
+	id            func() string
+	now           func() time.Time
+	suppressed    []error
+	renderLimit   int
+	precedence    Precedence
+	checkStats    bool
+	stacks        bool
+	panicFree     bool
+	reportHandled bool
+	metrics       Metrics
+	strict        bool
+}
+
+var (
+	// configMu - serialises Configure, readers don't need it
+	configMu sync.Mutex
+	// current - the *config in effect
+	current atomic.Value
+	// defaults - the config in effect before anything was configured
+	defaults config
+)
+
+// conf - the configuration currently in effect. Load it once and keep it where
+// several knobs are read together, so they're all from the same snapshot. Until the
+// first Configure that's the defaults; errors may well be made by package level vars,
+// before any init has run
+func conf() *config {
+	if c, ok := current.Load().(*config); ok {
+		return c
+	}
+	return &defaults
+}
+
+// Setting - a single change to the package configuration, see Configure
+type Setting func(c *config)
+
+// Configure - apply settings to the package wide configuration. Settings not
+// mentioned keep their current value. This is safe to call at any time, e.g. on
+// SIGHUP; the settings take effect together, an error being made or handled meanwhile
+// sees either all of them or none
+func Configure(settings ...Setting) {
+	configMu.Lock()
+	defer configMu.Unlock()
+	c := *conf()
+	for _, s := range settings {
+		s(&c)
+	}
+	current.Store(&c)
+}
+
+// CaptureGoroutines - when on, a Fatal error reaching ErrorHandler has a dump of every
+// goroutine attached to it before the handler and observers see it. Off by default,
+// dumping all goroutines is expensive and stops the world
+func CaptureGoroutines(on bool) Setting {
+	return func(c *config) {
+		c.goroutines = on
+	}
+}
@@ -0,0 +1,41 @@
+package eros
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestConfigureConcurrently(t *testing.T) {
+	defer Configure(CaptureStacks(false), RenderLimit(0))
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				_ = Wrap(New("flaky"), "retrying").Error()
+			}
+		}()
+	}
+	for i := 0; i < 100; i++ {
+		Configure(CaptureStacks(i%2 == 0), RenderLimit(i%5))
+	}
+	close(stop)
+	wg.Wait()
+
+	Configure(RenderLimit(7))
+	if got := renderLimit(); got != 7 {
+		t.Errorf("renderLimit() = %d, want 7", got)
+	}
+	Configure(CaptureStacks(true))
+	if c := conf(); !c.stacks || c.renderLimit != 7 {
+		t.Errorf("expected settings not mentioned to keep their value, got %+v", *c)
+	}
+}
@@ -0,0 +1,61 @@
+package eros
+
+import "context"
+
+// contextKey - the key an Error is kept under in a context.Context
+type contextKey struct{}
+
+// NewContext - a ctx carrying err, to describe the request (or job, ...) ctx belongs
+// to; its message, code and fields, e.g. the trace id. CheckCtx wraps everything it
+// raises in it, so layers further down don't have to pass any of it along by hand
+//
+//	ctx = eros.NewContext(ctx, eros.New("handling request").WithField("trace_id", id))
+func NewContext(ctx context.Context, err *Error) context.Context {
+	return context.WithValue(ctx, contextKey{}, err)
+}
+
+// FromContext - the Error ctx carries, see NewContext
+func FromContext(ctx context.Context) (*Error, bool) {
+	err, ok := ctx.Value(contextKey{}).(*Error)
+	return err, ok && err != nil
+}
+
+// CheckCtx - Check, wrapping err in what ctx carries (see NewContext) when it raises;
+// its message, code and fields. If ctx was done by then, err most likely failed
+// because of it; ctx.Err() is made the root cause and the chain coded Canceled or
+// DeadlineExceeded (and flagged a timeout), so handlers can tell the caller giving up
+// from a real failure. Otherwise this is Check
+func CheckCtx(ctx context.Context, err error, mesgs ...string) {
+	if err != nil {
+		countCheck(1)
+		raise(checkCtx(ctx, CastOrWrap(err, mesgs...)))
+	}
+}
+
+// CheckCtx - Check, as CheckCtx does it
+func (r Result[T]) CheckCtx(ctx context.Context, mesgs ...string) T {
+	if r.Error != nil {
+		countCheck(1)
+		raise(checkCtx(ctx, CastOrWrap(r.Error, mesgs...)))
+	}
+	return r.Value
+}
+
+// checkCtx - e as CheckCtx raises it
+func checkCtx(ctx context.Context, e *Error) *Error {
+	if cerr := ctx.Err(); cerr != nil {
+		e = ctxDone(wrap(cerr, "failed with the context done", 2), cerr).WithCause(e)
+	}
+	if meta, ok := FromContext(ctx); ok {
+		e = wrap(e, meta.text(), 2).WithCode(meta.code).WithFields(meta.fields)
+	}
+	return e
+}
+
+// ctxDone - e classified by cerr, the error of a done context
+func ctxDone(e *Error, cerr error) *Error {
+	if cerr == context.DeadlineExceeded {
+		return e.WithCode(DeadlineExceeded).WithTimeout(true)
+	}
+	return e.WithCode(Canceled)
+}
@@ -0,0 +1,64 @@
+package eros
+
+import (
+	"context"
+	"io"
+	"testing"
+)
+
+func TestCheckCtx(t *testing.T) {
+	if _, ok := FromContext(context.Background()); ok {
+		t.Errorf("expected nothing in an empty context")
+	}
+
+	ctx := NewContext(context.Background(), New("handling request").WithField("trace_id", "t-1"))
+	if meta, ok := FromContext(ctx); !ok || meta.Fields()["trace_id"] != "t-1" {
+		t.Errorf("FromContext() = %v, %v, want what was attached", meta, ok)
+	}
+
+	check := func(ctx context.Context, err error) (got *Error) {
+		defer ErrorHandler(func(err *Error) { got = err })()
+		CheckCtx(ctx, err)
+		return nil
+	}
+	if got := check(ctx, nil); got != nil {
+		t.Errorf("CheckCtx(nil) raised %v", got)
+	}
+	got := check(ctx, io.EOF)
+	if got.msg != "handling request" || got.Fields()["trace_id"] != "t-1" || !Is(got, io.EOF) {
+		t.Errorf("CheckCtx() raised %v, want err wrapped in the request's metadata", got)
+	}
+	if got := check(context.Background(), io.EOF); got.Fields()["trace_id"] != nil || !Is(got, io.EOF) {
+		t.Errorf("CheckCtx() raised %v, want plain Check without metadata", got)
+	}
+}
+
+func TestCheckCtxDone(t *testing.T) {
+	check := func(fn func()) (got *Error) {
+		defer ErrorHandler(func(err *Error) { got = err })()
+		fn()
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(NewContext(context.Background(), New("handling request")))
+	cancel()
+	got := check(func() { CheckCtx(ctx, io.ErrUnexpectedEOF) })
+	if got.Root() != context.Canceled || !Is(got, io.ErrUnexpectedEOF) || got.msg != "handling request" {
+		t.Errorf("CheckCtx() raised %v, want the cancellation as the root cause", got)
+	}
+	if HTTPStatus(got.Unwrap()) != 499 || IsTimeout(got) {
+		t.Errorf("expected the caller giving up told apart from a timeout")
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 0)
+	defer cancel()
+	<-ctx.Done()
+	got = check(func() { Result[int]{Error: io.ErrUnexpectedEOF}.CheckCtx(ctx) })
+	if got.Root() != context.DeadlineExceeded || Code(got) != DeadlineExceeded || !IsTimeout(got) {
+		t.Errorf("Result.CheckCtx() raised %v, want the deadline as a timeout", got)
+	}
+
+	if v := (Result[int]{Value: 1}).CheckCtx(ctx); v != 1 {
+		t.Errorf("Result.CheckCtx() = %v, want the value of an ok result", v)
+	}
+}
@@ -0,0 +1,107 @@
+package erosio
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+
+	"github.com/dawenga/eros"
+)
+
+/*
+	erosio is the stdlib file and stream plumbing most programs need, returning
+	Results. Every failure is wrapped as "failed to <op> <path>", carries the op and
+	path as fields and is coded from the taxonomy by what went wrong, so callers
+	can Check, Handle or map it to a response without inspecting os errors. It
+	doubles as the reference for how a package built on eros should look.
+*/
+
+// ReadFileResult - os.ReadFile
+func ReadFileResult(name string) (res eros.Result[[]byte]) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		res.Error = failed(err, "read", name)
+		return
+	}
+	res.Value = b
+	return
+}
+
+// WriteFileResult - os.WriteFile, the value being the number of bytes written
+func WriteFileResult(name string, data []byte, perm fs.FileMode) (res eros.Result[int]) {
+	if err := os.WriteFile(name, data, perm); err != nil {
+		res.Error = failed(err, "write", name)
+		return
+	}
+	res.Value = len(data)
+	return
+}
+
+// MkdirAllResult - os.MkdirAll, the value being path
+func MkdirAllResult(path string, perm fs.FileMode) (res eros.Result[string]) {
+	if err := os.MkdirAll(path, perm); err != nil {
+		res.Error = failed(err, "mkdir", path)
+		return
+	}
+	res.Value = path
+	return
+}
+
+// TempFileResult - os.CreateTemp. The caller owns the file, closing and removing it
+func TempFileResult(dir, pattern string) (res eros.Result[*os.File]) {
+	f, err := os.CreateTemp(dir, pattern)
+	if err != nil {
+		res.Error = failed(err, "create temp file in", dir)
+		return
+	}
+	res.Value = f
+	return
+}
+
+// CopyResult - io.Copy, the value being the number of bytes copied whether it failed
+// or not. The path of a failure is the name of src when it's a file (anything with a
+// Name), and both ends are attached as the src and dst fields
+func CopyResult(dst io.Writer, src io.Reader) (res eros.Result[int64]) {
+	n, err := io.Copy(dst, src)
+	res.Value = n
+	if err != nil {
+		res.Error = failed(err, "copy", name(src)).
+			WithField("src", name(src)).
+			WithField("dst", name(dst))
+	}
+	return
+}
+
+// failed - err wrapped, with the op and path as fields and coded by what went wrong.
+// The code goes in as an Opt so the error is counted with it, see CountErrors
+func failed(err error, op, path string) *eros.Error {
+	return eros.Wrap(err, fmt.Sprintf("failed to %s %s", op, path),
+		eros.WithCodeOpt(code(err)),
+		eros.WithFieldOpt("op", op),
+		eros.WithFieldOpt("path", path))
+}
+
+// code - the taxonomy's code for err
+func code(err error) string {
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return eros.NotFound
+	case errors.Is(err, fs.ErrExist):
+		return eros.AlreadyExists
+	case errors.Is(err, fs.ErrPermission):
+		return eros.PermissionDenied
+	case errors.Is(err, os.ErrDeadlineExceeded):
+		return eros.DeadlineExceeded
+	}
+	return eros.Internal
+}
+
+// name - the name of a stream which is a file, otherwise its type
+func name(stream interface{}) string {
+	if n, ok := stream.(interface{ Name() string }); ok {
+		return n.Name()
+	}
+	return fmt.Sprintf("%T", stream)
+}
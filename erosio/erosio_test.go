@@ -0,0 +1,76 @@
+package erosio
+
+import (
+	"bytes"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/dawenga/eros"
+)
+
+// failingReader - a stream that breaks
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) { return 0, io.ErrUnexpectedEOF }
+
+func TestResults(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "a", "b", "data.txt")
+
+	if got := MkdirAllResult(filepath.Dir(file), 0o755); got.Error != nil || got.Value != filepath.Dir(file) {
+		t.Fatalf("MkdirAllResult() = %v, %v", got.Value, got.Error)
+	}
+	if got := WriteFileResult(file, []byte("hello"), 0o644); got.Error != nil || got.Value != 5 {
+		t.Fatalf("WriteFileResult() = %v, %v", got.Value, got.Error)
+	}
+	if got := ReadFileResult(file); got.Error != nil || string(got.Value) != "hello" {
+		t.Fatalf("ReadFileResult() = %q, %v", got.Value, got.Error)
+	}
+	tmp := TempFileResult(dir, "copy-*")
+	if tmp.Error != nil {
+		t.Fatal(tmp.Error)
+	}
+	defer tmp.Value.Close()
+	if got := CopyResult(tmp.Value, bytes.NewBufferString("copied")); got.Error != nil || got.Value != 6 {
+		t.Fatalf("CopyResult() = %v, %v", got.Value, got.Error)
+	}
+
+	tests := []struct {
+		name     string
+		err      error
+		wantCode string
+		wantOp   string
+	}{
+		{"Test a missing file", ReadFileResult(filepath.Join(dir, "missing")).Error, eros.NotFound, "read"},
+		{"Test a file in the way", MkdirAllResult(filepath.Join(file, "sub"), 0o755).Error, eros.Internal, "mkdir"},
+		{"Test a missing dir", TempFileResult(filepath.Join(dir, "missing"), "x-*").Error, eros.NotFound, "create temp file in"},
+		{"Test a broken stream", CopyResult(io.Discard, failingReader{}).Error, eros.Internal, "copy"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, ok := tt.err.(*eros.Error)
+			if !ok {
+				t.Fatalf("expected an *eros.Error, got %v", tt.err)
+			}
+			if eros.Code(e) != tt.wantCode {
+				t.Errorf("code = %q, want %q", eros.Code(e), tt.wantCode)
+			}
+			if e.Fields()["op"] != tt.wantOp || e.Fields()["path"] == "" {
+				t.Errorf("fields = %v, want op %q and a path", e.Fields(), tt.wantOp)
+			}
+		})
+	}
+
+}
+
+func TestResultsCounted(t *testing.T) {
+	c := &eros.Counters{}
+	eros.Configure(eros.CountErrors(c))
+	defer eros.Configure(eros.CountErrors(nil))
+
+	ReadFileResult(filepath.Join(t.TempDir(), "missing.txt"))
+	if got := c.Snapshot(); len(got) != 1 || got[eros.MetricKey{Code: eros.NotFound, Severity: "warning"}] != 1 {
+		t.Errorf("Snapshot() = %v, want the failure counted once, coded", got)
+	}
+}
@@ -0,0 +1,105 @@
+package erostest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/dawenga/eros"
+)
+
+/*
+	erostest holds the assertions we use when testing code built on Eros. They all
+	understand the full chain (wrapped, nexted and caused errors) so a test can say
+	something about the structure of an error rather than just its string.
+*/
+
+// AssertIs - fail the test unless target is somewhere in err's chain
+func AssertIs(t testing.TB, err, target error) {
+	t.Helper()
+	if !eros.Is(err, target) {
+		t.Errorf("expected %q in the chain of %q", message(target), message(err))
+	}
+}
+
+// AssertNotIs - fail the test if target is anywhere in err's chain
+func AssertNotIs(t testing.TB, err, target error) {
+	t.Helper()
+	if err != nil && eros.Is(err, target) {
+		t.Errorf("did not expect %q in the chain of %q", message(target), message(err))
+	}
+}
+
+// AssertChainLen - fail the test unless err's chain has exactly n links, err included.
+// A nil error has a chain length of 0. This is what catches accidental extra wraps or
+// a cause that went missing along the way.
+func AssertChainLen(t testing.TB, err error, n int) {
+	t.Helper()
+	if got := chainLen(err); got != n {
+		t.Errorf("expected a chain of %d, got %d for %q", n, got, message(err))
+	}
+}
+
+// AssertNoCode - fail the test if any link in err's chain carries a code. A link
+// carries a code when it implements Code() string and returns something non empty.
+func AssertNoCode(t testing.TB, err error) {
+	t.Helper()
+	each(err, func(err error) bool {
+		if c, ok := err.(interface{ Code() string }); ok && c.Code() != "" {
+			t.Errorf("expected no code in the chain, found %q on %q", c.Code(), message(err))
+			return false
+		}
+		return true
+	})
+}
+
+// AssertStackContains - fail the test unless err's verbose (%+v) rendering mentions
+// frame, e.g. "pkg/foo" or "pkg/foo.Bar". Only errors that carry a stack trace will
+// ever pass.
+func AssertStackContains(t testing.TB, err error, frame string) {
+	t.Helper()
+	if err == nil {
+		t.Errorf("expected a stack containing %q, got a nil error", frame)
+		return
+	}
+	if !strings.Contains(fmt.Sprintf("%+v", err), frame) {
+		t.Errorf("expected the stack of %q to contain %q", message(err), frame)
+	}
+}
+
+// chainLen - the number of links in err's chain, err included
+func chainLen(err error) int {
+	n := 0
+	each(err, func(error) bool {
+		n++
+		return true
+	})
+	return n
+}
+
+// each - calls fn for every link in err's chain until fn returns false, in the order
+// eros walks them; Unwrap alone skips the cause of a link that also has a next (see
+// WithCause), so from the first eros Error on the chain is left to its Walk
+func each(err error, fn func(error) bool) {
+	for ; err != nil; err = eros.Unwrap(err) {
+		switch e := err.(type) {
+		case *eros.Error:
+			e.Walk(fn)
+			return
+		case eros.Error:
+			e.Walk(fn)
+			return
+		}
+		if !fn(err) {
+			return
+		}
+	}
+}
+
+// message - nil safe error string for failure messages
+func message(err error) string {
+	if err == nil {
+		return "<nil>"
+	}
+	return strings.TrimSpace(err.Error())
+}
@@ -0,0 +1,112 @@
+package erostest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dawenga/eros"
+	"github.com/pkg/errors"
+)
+
+// recordingT - captures failures instead of failing the real test
+type recordingT struct {
+	testing.TB
+	failed bool
+}
+
+func (r *recordingT) Helper() {}
+
+func (r *recordingT) Errorf(format string, args ...interface{}) {
+	r.failed = true
+}
+
+// codedError - stands in for any error type exposing a Code
+type codedError struct{ code string }
+
+func (c codedError) Error() string { return "coded " + c.code }
+func (c codedError) Code() string  { return c.code }
+
+func TestAssertions(t *testing.T) {
+	base := eros.New("base error")
+	tests := []struct {
+		name   string
+		assert func(t testing.TB)
+		fail   bool
+	}{
+		{
+			"AssertIs finds a wrapped target",
+			func(t testing.TB) { AssertIs(t, errors.Wrap(base, "wrapped"), base) },
+			false,
+		},
+		{
+			"AssertNotIs fails on a wrapped target",
+			func(t testing.TB) { AssertNotIs(t, errors.Wrap(base, "wrapped"), base) },
+			true,
+		},
+		{
+			"AssertNotIs passes on an unrelated error",
+			func(t testing.TB) { AssertNotIs(t, errors.New("other"), base) },
+			false,
+		},
+		{
+			"AssertChainLen counts every link",
+			func(t testing.TB) { AssertChainLen(t, eros.Wrap(errors.New("root"), "outer"), 2) },
+			false,
+		},
+		{
+			"AssertChainLen catches an extra wrap",
+			func(t testing.TB) {
+				AssertChainLen(t, eros.Wrap(eros.Wrap(errors.New("root"), "again"), "outer"), 2)
+			},
+			true,
+		},
+		{
+			"AssertChainLen of nil is 0",
+			func(t testing.TB) { AssertChainLen(t, nil, 0) },
+			false,
+		},
+		{
+			"AssertChainLen counts the cause of a link with a next",
+			func(t testing.TB) {
+				AssertChainLen(t, eros.Wrap(errors.New("root"), "outer").WithCause(eros.New("other")), 3)
+			},
+			false,
+		},
+		{
+			"AssertNoCode finds the code of the cause of a link with a next",
+			func(t testing.TB) {
+				AssertNoCode(t, eros.Wrap(codedError{"E1"}, "outer").WithCause(eros.New("other")))
+			},
+			true,
+		},
+		{
+			"AssertNoCode passes without codes",
+			func(t testing.TB) { AssertNoCode(t, eros.Wrap(base, "outer")) },
+			false,
+		},
+		{
+			"AssertNoCode finds a code deep in the chain",
+			func(t testing.TB) { AssertNoCode(t, eros.Wrap(fmt.Errorf("x: %w", codedError{"E1"}), "outer")) },
+			true,
+		},
+		{
+			"AssertStackContains finds the test function",
+			func(t testing.TB) { AssertStackContains(t, errors.New("with stack"), "erostest.TestAssertions") },
+			false,
+		},
+		{
+			"AssertStackContains fails on nil",
+			func(t testing.TB) { AssertStackContains(t, nil, "erostest") },
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &recordingT{TB: t}
+			tt.assert(rec)
+			if rec.failed != tt.fail {
+				t.Errorf("failed = %v, want %v", rec.failed, tt.fail)
+			}
+		})
+	}
+}
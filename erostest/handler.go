@@ -0,0 +1,35 @@
+package erostest
+
+import (
+	"testing"
+
+	"github.com/dawenga/eros"
+)
+
+// FailTestHandler - a handler marking t failed with the full chain of whatever it's
+// given, so a test can use the Check flow without any recover plumbing of its own;
+//
+//	defer eros.ErrorHandler(erostest.FailTestHandler(t))()
+func FailTestHandler(t testing.TB) eros.Handler {
+	return func(err *eros.Error) {
+		t.Helper()
+		t.Errorf("unexpected error: %+v", err)
+	}
+}
+
+// FatalTestHandler - FailTestHandler, which also stops the test
+func FatalTestHandler(t testing.TB) eros.Handler {
+	return func(err *eros.Error) {
+		t.Helper()
+		t.Fatalf("unexpected error: %+v", err)
+	}
+}
+
+// CheckT - Check for tests; unless err is nil the test is stopped with the full chain,
+// normalized as Check would raise it
+func CheckT(t testing.TB, err error, mesgs ...string) {
+	t.Helper()
+	if e := eros.CheckReturn(err, mesgs...); e != nil {
+		FatalTestHandler(t)(e)
+	}
+}
@@ -0,0 +1,48 @@
+package erostest
+
+import (
+	"testing"
+
+	"github.com/dawenga/eros"
+	"github.com/pkg/errors"
+)
+
+// fatalT - recordingT, which also records Fatalf
+type fatalT struct {
+	recordingT
+	fatal bool
+}
+
+func (f *fatalT) Fatalf(format string, args ...interface{}) {
+	f.failed, f.fatal = true, true
+}
+
+func TestHandlers(t *testing.T) {
+	rt := &fatalT{}
+	func() {
+		defer eros.ErrorHandler(FailTestHandler(rt))()
+		eros.Check(errors.New("boom"))
+	}()
+	if !rt.failed || rt.fatal {
+		t.Errorf("expected FailTestHandler to fail the test without stopping it")
+	}
+
+	rt = &fatalT{}
+	func() {
+		defer eros.ErrorHandler(FatalTestHandler(rt))()
+		eros.Check(errors.New("boom"))
+	}()
+	if !rt.fatal {
+		t.Errorf("expected FatalTestHandler to stop the test")
+	}
+
+	rt = &fatalT{}
+	CheckT(rt, nil)
+	if rt.failed {
+		t.Errorf("expected CheckT to pass a nil error")
+	}
+	CheckT(rt, errors.New("boom"), "loading")
+	if !rt.fatal {
+		t.Errorf("expected CheckT to stop the test")
+	}
+}
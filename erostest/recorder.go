@@ -0,0 +1,97 @@
+package erostest
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/dawenga/eros"
+)
+
+// Recorder - an observer collecting every error raised or recovered while a test runs,
+// so an integration test can assert the complete set of errors a flow produces.
+// Observers are package wide, so don't record in tests running in parallel
+type Recorder struct {
+	t       testing.TB
+	mu      sync.Mutex
+	pending map[string]int
+	errs    []*eros.Error
+}
+
+// StartRecorder - starts recording, until the test and its subtests complete
+func StartRecorder(t testing.TB) *Recorder {
+	rec := &Recorder{t: t, pending: map[string]int{}}
+	t.Cleanup(eros.Observe(rec.observe))
+	return rec
+}
+
+// observe - records raised errors, and recovered ones that weren't raised by eros
+// (e.g. a runtime panic). A raised error that gets recovered comes back as a copy
+// sharing its id, so it's only recorded once
+func (r *Recorder) observe(ev eros.Event, err *eros.Error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	switch ev {
+	case eros.Raised:
+		r.pending[err.ID()]++
+	case eros.Recovered:
+		if r.pending[err.ID()] > 0 {
+			r.pending[err.ID()]--
+			return
+		}
+	default:
+		return
+	}
+	r.errs = append(r.errs, err)
+}
+
+// Errors - everything recorded so far, in the order it happened
+func (r *Recorder) Errors() []*eros.Error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*eros.Error(nil), r.errs...)
+}
+
+// Fingerprints - the distinct fingerprints of everything recorded so far, in the order
+// they first happened
+func (r *Recorder) Fingerprints() []string {
+	var fps []string
+	seen := map[string]bool{}
+	for _, err := range r.Errors() {
+		if fp := eros.Fingerprint(err); !seen[fp] {
+			seen[fp] = true
+			fps = append(fps, fp)
+		}
+	}
+	return fps
+}
+
+// AssertOnly - fail the test unless every recorded error matches (eros.Is) one of
+// expected, and every one of expected was recorded
+func (r *Recorder) AssertOnly(expected ...error) {
+	r.t.Helper()
+	errs := r.Errors()
+	for _, err := range errs {
+		matched := false
+		for _, target := range expected {
+			if eros.Is(err, target) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			r.t.Errorf("unexpected error %q (fingerprint %s)", message(err), eros.Fingerprint(err))
+		}
+	}
+	for _, target := range expected {
+		found := false
+		for _, err := range errs {
+			if eros.Is(err, target) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			r.t.Errorf("expected error %q was never recorded", message(target))
+		}
+	}
+}
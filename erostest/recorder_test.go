@@ -0,0 +1,72 @@
+package erostest
+
+import (
+	"testing"
+
+	"github.com/dawenga/eros"
+)
+
+var (
+	errNotFound = eros.New("not found")
+	errTimeout  = eros.New("timeout")
+)
+
+// flow - raises err under a handler, as code under test would
+func flow(err error) {
+	defer eros.ErrorHandler(func(err *eros.Error) {})()
+	eros.Check(err)
+}
+
+func TestRecorder(t *testing.T) {
+	tests := []struct {
+		name     string
+		raised   []error
+		expected []error
+		fail     bool
+	}{
+		{
+			"Test exactly the expected errors",
+			[]error{errNotFound, errTimeout, errNotFound},
+			[]error{errNotFound, errTimeout},
+			false,
+		},
+		{
+			"Test an unexpected error",
+			[]error{errNotFound, errTimeout},
+			[]error{errNotFound},
+			true,
+		},
+		{
+			"Test an expected error that never happened",
+			[]error{errNotFound},
+			[]error{errNotFound, errTimeout},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &recordingT{TB: t}
+			rec := StartRecorder(fake)
+			for _, err := range tt.raised {
+				flow(err)
+			}
+			rec.AssertOnly(tt.expected...)
+			if fake.failed != tt.fail {
+				t.Errorf("failed = %v, want %v", fake.failed, tt.fail)
+			}
+		})
+	}
+}
+
+func TestRecorderFingerprints(t *testing.T) {
+	rec := StartRecorder(t)
+	flow(errNotFound)
+	flow(errTimeout)
+	flow(errNotFound)
+	if fps := rec.Fingerprints(); len(fps) != 2 || fps[0] != eros.Fingerprint(errNotFound) {
+		t.Errorf("Fingerprints() = %v, want those of not found and timeout", fps)
+	}
+	if n := len(rec.Errors()); n != 3 {
+		t.Errorf("Errors() recorded %d, want 3", n)
+	}
+}
@@ -0,0 +1,43 @@
+package eroswire
+
+import "time"
+
+/*
+	eroswire is the wire format of an eros error chain, as eros encodes it to JSON,
+	as plain structs. It imports nothing but the standard library so any Go service,
+	eros or not, can produce and consume the format; eros.ToWire and eros.FromWire
+	convert to and from an eros chain.
+*/
+
+// WireError - one link of an error chain on the wire, the rest of the chain nested
+// below it. Only Message is required
+type WireError struct {
+	// Message - what this link says went wrong, without the links below it
+	Message string `json:"message"`
+	// Count - the number of links below this one
+	Count int `json:"count,omitempty"`
+	// Code - a machine readable code, e.g. NOT_FOUND
+	Code string `json:"code,omitempty"`
+	// Fields - structured context; numbers decode as float64
+	Fields map[string]interface{} `json:"fields,omitempty"`
+	// ID - unique per error, for correlating logs across services
+	ID string `json:"id,omitempty"`
+	// Time - when the error was made
+	Time *time.Time `json:"time,omitempty"`
+	// Severity - -2 info, -1 warning, 0 (the default) an error, 1 fatal
+	Severity int `json:"severity,omitempty"`
+	// Status - the HTTP status this link maps to
+	Status int `json:"status,omitempty"`
+	// Retryable - whether the failure is transient, worth retrying
+	Retryable bool `json:"retryable,omitempty"`
+	// Timeout - whether the failure is a timeout, if this link says
+	Timeout *bool `json:"timeout,omitempty"`
+	// Temporary - whether the failure is temporary, if this link says
+	Temporary *bool `json:"temporary,omitempty"`
+	// Sentinel - the name of the sentinel this link stands for, e.g. ErrNoQuota
+	Sentinel string `json:"sentinel,omitempty"`
+	// Next - the next link in the chain
+	Next *WireError `json:"next,omitempty"`
+	// Cause - the cause of this link, after Next and everything below it
+	Cause *WireError `json:"cause,omitempty"`
+}
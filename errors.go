@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 )
 
 /*
@@ -20,12 +21,14 @@ import (
 
 // New - Just return an error and string
 func New(msg string) *Error {
-	return &Error{
-		msg,
-		nil,
-		nil,
-		0,
-	}
+	return newError(msg, 1)
+}
+
+// newError - New, capturing the stack skip frames above the caller of newError
+func newError(msg string, skip int) *Error {
+	return track(withCallers(stamp(&Error{
+		msg: msg,
+	}), skip+1))
 }
 
 // errorType - type of an error interface
@@ -33,7 +36,48 @@ var errorType = reflect.TypeOf((*error)(nil)).Elem()
 
 //Newf - New, with format. Just syntax sugar
 func Newf(format string, args ...interface{}) *Error {
-	return New(fmt.Sprintf(format, args...))
+	e := newError(fmt.Sprintf(format, args...), 1)
+	e.format = format
+	return e
+}
+
+// NewT - New, with a message formatted from template and args (as Newf) only when it's
+// needed. The template and the args are kept as given, see Template and Args, so the
+// message can be localized or redacted, or the args logged as they are
+//
+//	err := eros.NewT("user %d has no quota left on plan %s", id, plan)
+func NewT(template string, args ...interface{}) *Error {
+	e := newError("", 1)
+	e.format = template
+	// never nil, that's how a message formatted on demand is told apart
+	e.args = append([]interface{}{}, args...)
+	return e
+}
+
+// Template - the format this link's message was made with by Newf, Wrapf or NewT, ""
+// if none. This is nil safe
+func (e *Error) Template() string {
+	if e == nil {
+		return ""
+	}
+	return e.format
+}
+
+// Args - the args this link's message is formatted with on demand, see NewT. nil if it
+// wasn't made by NewT. This is nil safe
+func (e *Error) Args() []interface{} {
+	if e == nil {
+		return nil
+	}
+	return e.args
+}
+
+// text - the message of this link as created, formatted now if made by NewT
+func (e *Error) text() string {
+	if e.args != nil {
+		return fmt.Sprintf(e.format, e.args...)
+	}
+	return e.msg
 }
 
 //Count - returns the depth count of the errors
@@ -43,6 +87,7 @@ func (e Error) Count() int {
 
 //Error - implement the error interface
 func (e Error) Error() string {
+	mark(e)
 	cause := ""
 	if e.next != nil {
 		cause = e.next.Error()
@@ -50,7 +95,8 @@ func (e Error) Error() string {
 	if e.cause != nil {
 		cause = fmt.Sprintf(" %s\n root cause; %s", cause, e.cause.Error())
 	}
-	return fmt.Sprintf(" %s (cause count %d)\n%s", e.msg, e.count, cause)
+	msg, _ := e.render()
+	return fmt.Sprintf(" %s (cause count %d)\n%s", msg, e.count, cause)
 }
 
 //Unwrap - implement the Unwrap interface
@@ -65,31 +111,74 @@ func (e *Error) Unwrap() error {
 	return nil
 }
 
+// Is - implement the Is interface, so errors.Is of the standard library matches as
+// Is does; target is this same error, or one of ours with the same code and message,
+// or a sentinel this link carries (see Sentinel). Only this link is compared, the
+// chains are traversed by errors.Is
+func (e *Error) Is(target error) bool {
+	t := link(target)
+	if e == nil || t == nil {
+		return false
+	}
+	if t.sentinel != "" {
+		return e.sentinel == t.sentinel
+	}
+	return e == t || e.code == t.code && e.Error() == t.Error()
+}
+
+// As - implement the As interface, so errors.As of the standard library also assigns
+// to an Error rather than only to an *Error, as As does
+func (e *Error) As(target interface{}) bool {
+	if t, ok := target.(*Error); ok && e != nil {
+		*t = *e
+		return true
+	}
+	return false
+}
+
 // CastOrWrap - cast an interface error to an *Error. If not possible, wrap it.
 func CastOrWrap(err error, mesgs ...string) *Error {
 	msg := "cast to eros.Error"
 	if len(mesgs) > 0 {
 		msg = strings.Join(mesgs, ",")
 	}
+	mark(err)
 	de := dereference(err)
 	if e, ok := de.(Error); ok {
 		return &e
 	} else {
-		return Wrap(err, msg)
+		return wrap(err, msg, 1)
 	}
 }
 
-// Wrap - Wrap an error
-func Wrap(err error, msg string) *Error {
-	return &Error{
-		msg,
-		err,
-		nil,
-		1,
+// Wrap - Wrap an error. The Op/Path/Addr of *os.PathError, *os.LinkError and
+// *net.OpError are lifted into fields. opts are applied to the new link, see Build
+func Wrap(err error, msg string, opts ...Opt) *Error {
+	e := wrap(err, msg, 1)
+	if apply(e, opts) && !hasStack(e) {
+		e.stack = callers(1)
 	}
+	countError(e)
+	return e
 }
 
-//WithCause - appends a new cause error to the chain. This is nil safe
+// wrap - Wrap, capturing the stack skip frames above the caller of wrap unless err's
+// chain already has one; the deepest stack is the one that says where it started
+func wrap(err error, msg string, skip int) *Error {
+	mark(err)
+	e := liftFields(stamp(&Error{
+		msg:   msg,
+		cause: err,
+		count: 1,
+	}), err)
+	if conf().stacks && !hasStack(err) {
+		withCallers(e, skip+1)
+	}
+	return track(e)
+}
+
+//WithCause - appends a new cause error to the chain. This is nil safe. The chain is
+// changed in place, see Sync for goroutines adding to the same chain at once
 func (e *Error) WithCause(err error) *Error {
 	if e == nil {
 		e = CastOrWrap(err)
@@ -107,30 +196,158 @@ func (e *Error) WithCause(err error) *Error {
 
 // Wrapf - Wrap an error... with formatting
 func Wrapf(err error, msg string, vars ...interface{}) *Error {
-	return Wrap(err, fmt.Sprintf(msg, vars...))
+	e := wrap(err, fmt.Sprintf(msg, vars...), 1)
+	e.format = msg
+	countError(e)
+	return e
 }
 
-// Is - test for equality
-func Is(err, target error) bool {
-	if target == nil {
-		return err == target
+// WrapAll - Wrap several errors, e.g. those of parallel cleanups, under one message.
+// Nils are dropped and the rest chained as causes; nil is returned if all were nil
+func WrapAll(msg string, errs ...error) *Error {
+	var res *Error
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if res == nil {
+			res = Wrap(err, msg)
+		} else {
+			res.WithCause(err)
+		}
 	}
+	return res
+}
 
-	isComparable := reflect.TypeOf(target).Comparable()
-	for {
-		if isComparable && err == target {
-			return true
+// WrapOnce - Wrap, unless err is already an eros Error with msg as its outermost
+// message, or as the template of it (see Wrapf), in which case err itself is returned.
+// This keeps retried middleware and loops from stacking identical layers on the same
+// chain
+func WrapOnce(err error, msg string) *Error {
+	if e := link(err); e != nil && (e.text() == msg || e.format != "" && e.format == msg) {
+		return e
+	}
+	return Wrap(err, msg)
+}
+
+// ReplaceCause - puts new in place of the first link below err matching old (as Is
+// matches a link), in the order Walk visits them, keeping the rest of the chain as
+// it was; e.g. to swap a driver error for a sanitized equivalent. Only links of ours
+// can be rewired, a match below an error of another kind isn't found. Whether a link
+// was replaced
+func ReplaceCause(err *Error, old, new error) bool {
+	if err == nil || old == nil || new == nil {
+		return false
+	}
+	return rechain(err, old, func(error) error {
+		return new
+	})
+}
+
+// Detach - err with the first link matching target (as Is matches a link) taken out
+// of its chain, what was below the link taking its place. If err itself matches,
+// what was below it is returned. err is returned as it was if nothing matches
+func Detach(err *Error, target error) *Error {
+	if err == nil || target == nil {
+		return err
+	}
+	if is(err, target, reflect.TypeOf(target).Comparable()) {
+		if b := below(err); b != nil {
+			return CastOrWrap(b)
 		}
-		if isComparable && err.Error() == target.Error() {
-			return true
+		return nil
+	}
+	rechain(err, target, below)
+	return err
+}
+
+// below - what's left of a chain once its outermost link, l, is taken out
+func below(l error) error {
+	e := link(l)
+	switch {
+	case e == nil:
+		return Unwrap(l)
+	case e.next == nil:
+		return e.cause
+	case e.cause == nil:
+		return e.next
+	}
+	return e.next.WithCause(e.cause)
+}
+
+// rechain - puts with(l) in place of the first link l below e matching target, in
+// the order walk visits them; a nil with(l) drops it. Whether one was found
+func rechain(e *Error, target error, with func(error) error) bool {
+	isComparable := reflect.TypeOf(target).Comparable()
+	found := false
+	if e.next != nil {
+		if is(e.next, target, isComparable) {
+			r := with(e.next)
+			e.next, found = nil, true
+			if r != nil {
+				e.next = CastOrWrap(r)
+			}
+		} else {
+			found = rechain(e.next, target, with)
 		}
-		if x, ok := err.(interface{ Is(error) bool }); ok && x.Is(target) {
-			return true
+	}
+	if !found && e.cause != nil {
+		if is(e.cause, target, isComparable) {
+			e.cause, found = with(e.cause), true
+		} else if c, ok := e.cause.(*Error); ok {
+			found = rechain(c, target, with)
 		}
-		if err = Unwrap(err); err == nil {
-			return false
+	}
+	if found {
+		// the depth below e may have changed, counted as WithCause and Wrap do
+		switch {
+		case e.next != nil:
+			e.count = e.next.count + 1
+		case e.cause != nil:
+			e.count = 1
+		default:
+			e.count = 0
 		}
 	}
+	return found
+}
+
+// Is - test for equality. Any link in the chain may implement Matches(error) bool
+// to decide for itself whether it matches target (e.g. same code, any message), this
+// is consulted before equality and only by eros, std errors.Is is unaffected.
+func Is(err, target error) bool {
+	if target == nil {
+		return err == target
+	}
+
+	isComparable := reflect.TypeOf(target).Comparable()
+	found := false
+	walk(err, func(err error) bool {
+		found = is(err, target, isComparable)
+		return !found
+	})
+	return found
+}
+
+// is - whether this one link matches target. A sentinel is only matched by the links
+// carrying it, see Sentinel
+func is(err, target error, isComparable bool) bool {
+	if name := linkSentinel(target); name != "" {
+		return linkSentinel(err) == name
+	}
+	if x, ok := err.(interface{ Matches(error) bool }); ok && x.Matches(target) {
+		return true
+	}
+	if isComparable && err == target {
+		return true
+	}
+	if isComparable && err.Error() == target.Error() {
+		return true
+	}
+	if x, ok := err.(interface{ Is(error) bool }); ok && x.Is(target) {
+		return true
+	}
+	return false
 }
 
 // dereference. As only works with instances, not pointers
@@ -162,18 +379,18 @@ func As(err error, target interface{}) bool {
 	if targetType.Kind() != reflect.Interface && !targetType.Implements(errorType) {
 		panic("errors: *target must be interface or implement error")
 	}
-	for err != nil {
+	found := false
+	walk(err, func(err error) bool {
 		de := dereference(err)
 		if reflect.TypeOf(de).AssignableTo(targetType) {
 			val.Elem().Set(reflect.ValueOf(de))
-			return true
+			found = true
+		} else if x, ok := de.(interface{ As(interface{}) bool }); ok && x.As(target) {
+			found = true
 		}
-		if x, ok := de.(interface{ As(interface{}) bool }); ok && x.As(target) {
-			return true
-		}
-		err = Unwrap(err)
-	}
-	return false
+		return !found
+	})
+	return found
 }
 
 // Unwrap -  unwrap an error
@@ -187,10 +404,111 @@ func Unwrap(err error) error {
 	return u.Unwrap()
 }
 
+// walk - calls fn for every error in err's chain until fn returns false. Unwrap only
+// follows next when a link of ours has both a next and a cause, so the cause is
+// visited afterwards rather than skipped; the order Error() renders them in. The
+// errors of a multi error (Unwrap() []error, e.g. Join) are visited in order
+func walk(err error, fn func(error) bool) {
+	var forks []error
+	for err != nil || len(forks) > 0 {
+		if err == nil {
+			err, forks = forks[len(forks)-1], forks[:len(forks)-1]
+		}
+		if !fn(err) {
+			return
+		}
+		if m, ok := err.(interface{ Unwrap() []error }); ok {
+			errs := m.Unwrap()
+			for i := len(errs) - 1; i > 0; i-- {
+				forks = append(forks, errs[i])
+			}
+			err = nil
+			if len(errs) > 0 {
+				err = errs[0]
+			}
+			continue
+		}
+		if e := link(err); e != nil && e.next != nil && e.cause != nil {
+			forks = append(forks, e.cause)
+		}
+		err = Unwrap(err)
+	}
+}
+
+// Walk - calls fn for every error in the chain, this one first, until fn returns
+// false. Both the next and the cause of a link are visited, next first, as are the
+// errors of a multi error, in order. This is nil safe
+func (e *Error) Walk(fn func(error) bool) {
+	if e != nil {
+		walk(e, fn)
+	}
+}
+
+// Causes - every error in the chain, in the order Walk visits them, this one first.
+// This is nil safe
+func (e *Error) Causes() []error {
+	var errs []error
+	e.Walk(func(err error) bool {
+		errs = append(errs, err)
+		return true
+	})
+	return errs
+}
+
+// Root - the deepest cause in the chain, the last error Walk visits. This one if
+// nothing is below it. This is nil safe
+func (e *Error) Root() error {
+	if e == nil {
+		return nil
+	}
+	return root(e)
+}
+
+// root - the last error in err's chain walk visits
+func root(err error) error {
+	walk(err, func(link error) bool {
+		err = link
+		return true
+	})
+	return err
+}
+
+// message - the message of this one link in err's chain, without the links below it.
+// Errors that aren't ours usually render as "msg: inner", so the inner part is trimmed
+func message(err error) string {
+	if e := link(err); e != nil {
+		msg, _ := e.render()
+		return msg
+	}
+	msg := err.Error()
+	if next := Unwrap(err); next != nil {
+		msg = strings.TrimSuffix(strings.TrimSuffix(msg, next.Error()), ": ")
+	}
+	return msg
+}
+
 // Error - our own version of an error, which can wrap others
 type Error struct {
-	msg   string
-	cause error
-	next  *Error
-	count int
+	msg        string
+	cause      error
+	next       *Error
+	count      int
+	severity   Severity
+	goroutines []byte
+	handled    *int32
+	fields     map[string]interface{}
+	code       string
+	id         string
+	at         time.Time
+	stack      []uintptr
+	fault      Fault
+	rendered   bool
+	format     string
+	msgID      uint64
+	status     int
+	retryable  bool
+	timeout    flag
+	temporary  flag
+	sentinel   string
+	args       []interface{}
 }
@@ -1,6 +1,8 @@
 package eros
 
 import (
+	"io"
+	"strings"
 	"testing"
 
 	"github.com/pkg/errors"
@@ -11,6 +13,19 @@ var (
 	ComparedErrorInstance = New("This is an eros Error")
 )
 
+// kindError - a domain error that matches any other kindError of the same kind,
+// whatever the message
+type kindError struct {
+	kind, msg string
+}
+
+func (k kindError) Error() string { return k.kind + ": " + k.msg }
+
+func (k kindError) Matches(target error) bool {
+	t, ok := target.(kindError)
+	return ok && t.kind == k.kind
+}
+
 func TestAs(t *testing.T) {
 	var e Error
 	type args struct {
@@ -99,6 +114,22 @@ func TestIs(t *testing.T) {
 			},
 			true,
 		},
+		{
+			"Test Error that matches through its Matches method",
+			args{
+				Wrap(kindError{"not found", "no such user"}, "failed to load user"),
+				kindError{"not found", "any message"},
+			},
+			true,
+		},
+		{
+			"Test Error that doesn't match through its Matches method",
+			args{
+				Wrap(kindError{"not found", "no such user"}, "failed to load user"),
+				kindError{"conflict", "no such user"},
+			},
+			false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -148,3 +179,253 @@ func TestUnwrap(t *testing.T) {
 		})
 	}
 }
+
+func TestWrapOnce(t *testing.T) {
+	root := errors.New("connection refused")
+	once := WrapOnce(root, "calling upstream")
+	tests := []struct {
+		name     string
+		err      error
+		msg      string
+		wantSame bool
+	}{
+		{
+			"Test wraps the first time",
+			root,
+			"calling upstream",
+			false,
+		},
+		{
+			"Test doesn't wrap the same message twice",
+			once,
+			"calling upstream",
+			true,
+		},
+		{
+			"Test wraps a different outermost message",
+			Wrap(once, "retrying"),
+			"calling upstream",
+			false,
+		},
+		{
+			"Test doesn't wrap the same template twice",
+			Wrapf(root, "calling %s", "upstream"),
+			"calling %s",
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := WrapOnce(tt.err, tt.msg)
+			if same := error(got) == tt.err; same != tt.wantSame {
+				t.Errorf("WrapOnce() returned err itself = %v, want %v", same, tt.wantSame)
+			}
+		})
+	}
+}
+
+func TestWrapAll(t *testing.T) {
+	errA := errors.New("close db")
+	errB := errors.New("flush cache")
+	tests := []struct {
+		name    string
+		errs    []error
+		wantNil bool
+		want    []error
+	}{
+		{
+			"Test nothing failed",
+			[]error{nil, nil},
+			true,
+			nil,
+		},
+		{
+			"Test no errors at all",
+			nil,
+			true,
+			nil,
+		},
+		{
+			"Test one failure among nils",
+			[]error{nil, errA, nil},
+			false,
+			[]error{errA},
+		},
+		{
+			"Test every failure is a cause",
+			[]error{errA, nil, errB},
+			false,
+			[]error{errA, errB},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := WrapAll("cleanup failed", tt.errs...)
+			if (got == nil) != tt.wantNil {
+				t.Fatalf("WrapAll() = %v, wantNil %v", got, tt.wantNil)
+			}
+			if got == nil {
+				return
+			}
+			if got.msg != "cleanup failed" {
+				t.Errorf("WrapAll() message = %q, want %q", got.msg, "cleanup failed")
+			}
+			for _, want := range tt.want {
+				if !Is(got, want) {
+					t.Errorf("WrapAll() = %v, want %v among the causes", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestWalk(t *testing.T) {
+	root := errors.New("connection refused")
+	err := Wrap(Wrap(root, "dial"), "query")
+
+	var msgs []string
+	err.Walk(func(link error) bool {
+		msgs = append(msgs, message(link))
+		return len(msgs) < 2
+	})
+	if len(msgs) != 2 || msgs[0] != "query" || msgs[1] != "dial" {
+		t.Errorf("Walk() visited %q, want query then dial and to stop there", msgs)
+	}
+
+	if causes := err.Causes(); len(causes) != 3 || causes[0] != error(err) || causes[2] != root {
+		t.Errorf("Causes() = %v, want the three links outermost first", causes)
+	}
+	if got := err.Root(); got != root {
+		t.Errorf("Root() = %v, want %v", got, root)
+	}
+
+	var nilErr *Error
+	if nilErr.Causes() != nil || nilErr.Root() != nil {
+		t.Errorf("expected a nil Error to have no causes and no root")
+	}
+	if alone := New("alone"); alone.Root() != error(alone) {
+		t.Errorf("expected an Error with nothing below to be its own root")
+	}
+}
+
+func TestReplaceCause(t *testing.T) {
+	driver := errors.New("pq: password authentication failed for user admin")
+	err := Wrap(driver, "connecting").WithCause(New("loading users"))
+	sanitized := New("database unavailable")
+	if !ReplaceCause(err, driver, sanitized) {
+		t.Fatal("ReplaceCause() = false, want the driver error replaced")
+	}
+	if Is(err, driver) || !Is(err, sanitized) {
+		t.Errorf("ReplaceCause() left %v, want the sanitized cause", err)
+	}
+	if !Is(err, New("loading users")) {
+		t.Errorf("ReplaceCause() left %v, want the rest of the chain kept", err)
+	}
+	if ReplaceCause(err, driver, sanitized) {
+		t.Error("ReplaceCause() = true, want false with nothing left to replace")
+	}
+}
+
+func TestDetach(t *testing.T) {
+	middle := New("middle")
+	err := Wrap(errStepFailed, "outer").WithCause(middle)
+	if got := Detach(err, middle); got != err || Is(got, middle) || !Is(got, errStepFailed) {
+		t.Errorf("Detach() = %v, want the chain without the middle link", got)
+	}
+	if got := Detach(err, errStepFailed); Is(got, errStepFailed) || got.Count() != 0 {
+		t.Errorf("Detach() = %v, want the chain without its root cause", got)
+	}
+	top := Wrap(errStepFailed, "top")
+	if got := Detach(top, top); !Is(got, errStepFailed) || Is(got, New("top")) {
+		t.Errorf("Detach() = %v, want what was below the detached link", got)
+	}
+}
+
+func TestStdlibIsAs(t *testing.T) {
+	if !errors.Is(ComparedErrorInstance, NewErrorInstance) {
+		t.Errorf("expected errors.Is to match errors of the same message, as Is does")
+	}
+	if errors.Is(New("same").WithCode(NotFound), New("same").WithCode(Internal)) {
+		t.Errorf("expected errors.Is not to match errors of different codes")
+	}
+	wrapped := Wrap(Wrap(errStepFailed, "loading"), "handling")
+	if !errors.Is(wrapped, errStepFailed) {
+		t.Errorf("expected errors.Is to find the cause down the chain")
+	}
+
+	var e Error
+	if !errors.As(Wrap(errStepFailed, "loading"), &e) || e.msg != "loading" {
+		t.Errorf("errors.As() = %v, want the Error assigned", e)
+	}
+}
+
+func TestNewT(t *testing.T) {
+	err := NewT("user %d has no quota left on plan %s", 7, "free")
+	if err.Template() != "user %d has no quota left on plan %s" || len(err.Args()) != 2 || err.Args()[0] != 7 {
+		t.Errorf("NewT() kept %q and %v, want the template and args as given", err.Template(), err.Args())
+	}
+	if got := message(err); got != "user 7 has no quota left on plan free" {
+		t.Errorf("message() = %q, want it formatted on demand", got)
+	}
+	if ToWire(err).Message != "user 7 has no quota left on plan free" {
+		t.Errorf("ToWire() = %q, want the formatted message", ToWire(err).Message)
+	}
+	if Fingerprint(err) != Fingerprint(NewT("user %d has no quota left on plan %s", 8, "pro")) {
+		t.Errorf("expected errors of the same template to share a fingerprint")
+	}
+	if New("plain").Args() != nil || NewT("no args").Args() == nil {
+		t.Errorf("expected only NewT's errors to have args")
+	}
+}
+
+// TestTraversalsFollowCauses - a link given a next by WithCause still has the cause it
+// wrapped, every traversal must reach it
+func TestTraversalsFollowCauses(t *testing.T) {
+	chain := func() *Error {
+		return Wrap(New("x").WithCode("A").WithSeverity(Fatal).WithField("k", "v"), "outer").
+			WithCause(New("other"))
+	}
+	err := chain()
+	if got := Code(err); got != "A" {
+		t.Errorf("Code() = %q, want A", got)
+	}
+	if got := SeverityOf(err); got != Fatal {
+		t.Errorf("SeverityOf() = %v, want Fatal", got)
+	}
+	ClassifyCode("A", ClientFault)
+	defer ClassifyCode("A", Unclassified)
+	if got := FaultOf(err); got != ClientFault {
+		t.Errorf("FaultOf() = %v, want the fault of the code", got)
+	}
+	if got := err.AllFields()["k"]; got != "v" {
+		t.Errorf("AllFields() = %v, want the field of the cause", err.AllFields())
+	}
+	if Fingerprint(err) == Fingerprint(Wrap(New("x"), "outer").WithCause(New("other"))) {
+		t.Errorf("expected the cause to take part in the fingerprint")
+	}
+	if got := DepthOf[*Error](Wrap(io.EOF, "outer").WithCause(New("other"))); got != 0 {
+		t.Errorf("DepthOf() = %d, want 0", got)
+	}
+	if !strings.Contains(string(ToHTML(err)), "<summary>x ") {
+		t.Errorf("ToHTML() = %s, want the cause rendered", ToHTML(err))
+	}
+	var sb strings.Builder
+	if werr := WriteJSON(&sb, err); werr != nil || !strings.Contains(sb.String(), `"message":"x"`) {
+		t.Errorf("WriteJSON() = %s, want the cause written", sb.String())
+	}
+	if err := RegisterTemplate("A", "public x"); err != nil {
+		t.Fatal(err)
+	}
+	defer RegisterTemplate("A", "")
+	if got := PublicMessage(chain()); got != "public x" {
+		t.Errorf("PublicMessage() = %q, want the cause's template", got)
+	}
+
+	Configure(CaptureStacks(true))
+	defer Configure(CaptureStacks(false))
+	withStack := New("deep")
+	Configure(CaptureStacks(false))
+	if !hasStack(Wrap(withStack, "outer").WithCause(New("other"))) {
+		t.Errorf("expected the stack of the cause to be found")
+	}
+}
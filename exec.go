@@ -0,0 +1,120 @@
+package eros
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// maxOutput - the most process output we keep in a field, the rest is truncated
+const maxOutput = 4 << 10
+
+// ExecFailed - the code of errors from RunResult
+const ExecFailed = "EXEC_FAILED"
+
+// FromExitError - turns the failure of a child process into a structured chain. For
+// an *exec.ExitError the last line the child wrote to stderr (as captured by e.g.
+// Cmd.Output) is taken to be its error; decoded if it's an eros chain written as
+// JSON, otherwise split on ": ", Go's wrapping convention, into a link per message,
+// outermost first. Either way the ExitError is the root cause. The exit code and the
+// stderr are attached to the outermost link as fields. Note that an ExitError
+// doesn't know its command, see RunResult for that. Any other error is simply
+// CastOrWrap'd
+func FromExitError(err error) *Error {
+	if err == nil {
+		return nil
+	}
+	ee, ok := err.(*exec.ExitError)
+	if !ok {
+		return CastOrWrap(err)
+	}
+
+	res := childChain(ee, ee.Stderr).WithField("exit_code", ee.ExitCode())
+	if len(ee.Stderr) > 0 {
+		res.WithField("stderr", truncate(string(ee.Stderr), maxOutput))
+	}
+	countError(res)
+	return res
+}
+
+// RunResult - runs cmd and returns its combined output. A failure to start or a non
+// zero exit is coded ExecFailed, with the child's last line of output parsed into the
+// chain as FromExitError does, and the command, args, exit code and (truncated)
+// output attached as fields
+func RunResult(cmd *exec.Cmd) (res Result[[]byte]) {
+	out, err := cmd.CombinedOutput()
+	res.Value = out
+	if err == nil {
+		return
+	}
+	msg := fmt.Sprintf("failed to run %s", cmd.Path)
+	var e *Error
+	if ee, ok := err.(*exec.ExitError); ok {
+		e = wrap(childChain(ee, out), msg, 1).WithField("exit_code", ee.ExitCode())
+	} else {
+		e = wrap(err, msg, 1)
+	}
+	e.format = "failed to run %s"
+	args := []string{}
+	if len(cmd.Args) > 1 {
+		args = cmd.Args[1:]
+	}
+	e.WithCode(ExecFailed).
+		WithField("command", cmd.Path).
+		WithField("args", args).
+		WithField("output", truncate(string(out), maxOutput))
+	countError(e)
+	res.Error = e
+	return
+}
+
+// childChain - the error a child process reported as a chain rooted at root. The
+// last line of output is taken to be the child's error; an eros chain if the child
+// wrote one as JSON, otherwise it's split on ": " into a link per message
+func childChain(root error, output []byte) *Error {
+	var msgs []string
+	if out := strings.TrimSpace(string(output)); out != "" {
+		lines := strings.Split(out, "\n")
+		last := strings.TrimSpace(lines[len(lines)-1])
+		if chain := childJSON(root, last); chain != nil {
+			return chain
+		}
+		msgs = strings.Split(last, ": ")
+	}
+	if len(msgs) == 0 {
+		msgs = []string{"child process failed"}
+	}
+	// the child's failure, not ours; it's counted once, as the whole chain
+	chain := root
+	for i := len(msgs) - 1; i >= 0; i-- {
+		chain = wrap(chain, msgs[i], 1)
+	}
+	return chain.(*Error)
+}
+
+// childJSON - the chain a child wrote as JSON (see MarshalJSON) on line, rooted at
+// root. nil if line isn't one
+func childJSON(root error, line string) *Error {
+	if !strings.HasPrefix(line, "{") {
+		return nil
+	}
+	var chain Error
+	if err := json.Unmarshal([]byte(line), &chain); err != nil || chain.msg == "" {
+		return nil
+	}
+	deepest := &chain
+	for next := deepest.Unwrap(); next != nil; next = deepest.Unwrap() {
+		deepest = next.(*Error)
+	}
+	deepest.cause = root
+	return &chain
+}
+
+// truncate - s cut down to at most max bytes, saying how much was lost
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return fmt.Sprintf("%s... (%d bytes truncated)", s[:max], len(s)-max)
+}
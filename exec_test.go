@@ -0,0 +1,68 @@
+package eros
+
+import (
+	"os/exec"
+	"reflect"
+	"testing"
+)
+
+func TestFromExitError(t *testing.T) {
+	_, err := exec.Command("sh", "-c", "echo starting >&2; echo 'loading config: open app.yaml: no such file' >&2; exit 3").Output()
+	got := FromExitError(err)
+
+	var msgs []string
+	for e := error(got); e != nil; e = Unwrap(e) {
+		msgs = append(msgs, message(e))
+	}
+	want := []string{"loading config", "open app.yaml", "no such file", "exit status 3"}
+	if !reflect.DeepEqual(msgs, want) {
+		t.Errorf("FromExitError() chain = %q, want %q", msgs, want)
+	}
+	if code := got.Fields()["exit_code"]; code != 3 {
+		t.Errorf("FromExitError() exit_code = %v, want 3", code)
+	}
+	var ee *exec.ExitError
+	if !As(got, &ee) {
+		t.Errorf("FromExitError() lost the *exec.ExitError")
+	}
+	if FromExitError(nil) != nil {
+		t.Errorf("FromExitError(nil) should be nil")
+	}
+}
+
+func TestRunResult(t *testing.T) {
+	res := RunResult(exec.Command("sh", "-c", "echo hello"))
+	if res.Error != nil || string(res.Value) != "hello\n" {
+		t.Errorf("RunResult() = %q, %v, want hello", res.Value, res.Error)
+	}
+
+	cmd := exec.Command("sh", "-c", "echo 'bad input: missing name'; exit 2")
+	res = RunResult(cmd)
+	if Code(res.Error) != ExecFailed {
+		t.Errorf("RunResult() code = %q, want %q", Code(res.Error), ExecFailed)
+	}
+	fields := res.Error.(*Error).Fields()
+	if fields["exit_code"] != 2 || !reflect.DeepEqual(fields["args"], []string{"-c", "echo 'bad input: missing name'; exit 2"}) {
+		t.Errorf("RunResult() fields = %v", fields)
+	}
+	var msgs []string
+	for e := res.Error; e != nil; e = Unwrap(e) {
+		msgs = append(msgs, message(e))
+	}
+	want := []string{"failed to run " + cmd.Path, "bad input", "missing name", "exit status 2"}
+	if !reflect.DeepEqual(msgs, want) {
+		t.Errorf("RunResult() chain = %q, want %q", msgs, want)
+	}
+}
+
+func TestRunResultCounted(t *testing.T) {
+	c := &Counters{}
+	Configure(CountErrors(c))
+	defer Configure(CountErrors(nil))
+
+	RunResult(exec.Command("sh", "-c", "echo 'bad input: missing name'; exit 2"))
+	want := map[MetricKey]uint64{{ExecFailed, "error"}: 1}
+	if got := c.Snapshot(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Snapshot() = %v, want the failure counted once, coded", got)
+	}
+}
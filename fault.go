@@ -0,0 +1,84 @@
+package eros
+
+// Fault - whose fault an error is, the client's (bad input, no permission; 4xx) or
+// ours (5xx). Availability SLOs usually only count server faults
+type Fault int
+
+const (
+	// Unclassified - nothing in the chain says whose fault it is
+	Unclassified Fault = iota
+	// ClientFault - the caller did something wrong, retrying as is won't help
+	ClientFault
+	// ServerFault - we failed the caller
+	ServerFault
+)
+
+// String - implement the Stringer interface
+func (f Fault) String() string {
+	switch f {
+	case ClientFault:
+		return "client"
+	case ServerFault:
+		return "server"
+	}
+	return "unclassified"
+}
+
+// ClassifyCode - declares whose fault errors coded code are, keeping whatever else
+// was declared for it with RegisterCode
+func ClassifyCode(code string, f Fault) {
+	codesMu.Lock()
+	defer codesMu.Unlock()
+	info := codes[code]
+	info.Fault = f
+	codes[code] = info
+}
+
+// WithFault - explicitly classifies this link in the chain. This is nil safe
+func (e *Error) WithFault(f Fault) *Error {
+	if e != nil {
+		e.fault = f
+	}
+	return e
+}
+
+// FaultOf - whose fault err is. The chain is walked outermost first and the first link
+// to say decides; by its explicit fault, then the fault declared for its code with
+// RegisterCode or ClassifyCode, then by its HTTP status, see WithHTTPStatus, or its
+// HTTPStatus() int or StatusCode() int if it has one
+func FaultOf(err error) Fault {
+	f := Unclassified
+	walk(err, func(l error) bool {
+		f = linkFault(l)
+		return f == Unclassified
+	})
+	return f
+}
+
+// IsClientFault - whether err is the client's fault
+func IsClientFault(err error) bool {
+	return err != nil && FaultOf(err) == ClientFault
+}
+
+// IsServerFault - whether err is our fault. Errors nobody classified count as ours,
+// an SLO is better off too strict than too lenient
+func IsServerFault(err error) bool {
+	return err != nil && FaultOf(err) != ClientFault
+}
+
+// linkFault - whose fault this one link in the chain says it is
+func linkFault(err error) Fault {
+	if e := link(err); e != nil && e.fault != Unclassified {
+		return e.fault
+	}
+	if info, ok := LookupCode(linkCode(err)); ok && info.Fault != Unclassified {
+		return info.Fault
+	}
+	switch status := linkStatus(err); {
+	case status >= 500:
+		return ServerFault
+	case status >= 400:
+		return ClientFault
+	}
+	return Unclassified
+}
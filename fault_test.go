@@ -0,0 +1,98 @@
+package eros
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+// statusError - stands in for an http client error exposing its status
+type statusError int
+
+func (s statusError) Error() string   { return "unexpected status" }
+func (s statusError) StatusCode() int { return int(s) }
+
+func TestFaultOf(t *testing.T) {
+	ClassifyCode("TEST_INVALID", ClientFault)
+	tests := []struct {
+		name   string
+		err    error
+		want   Fault
+		client bool
+		server bool
+	}{
+		{
+			"Test an unclassified error counts as the server's",
+			errors.New("boom"),
+			Unclassified,
+			false,
+			true,
+		},
+		{
+			"Test an explicit fault",
+			Wrap(errors.New("bad input"), "validating").WithFault(ClientFault),
+			ClientFault,
+			true,
+			false,
+		},
+		{
+			"Test a classified code deep in the chain",
+			errors.Wrap(New("missing name").WithCode("TEST_INVALID"), "creating user"),
+			ClientFault,
+			true,
+			false,
+		},
+		{
+			"Test the outermost classification wins",
+			Wrap(statusError(404), "calling upstream").WithFault(ServerFault),
+			ServerFault,
+			false,
+			true,
+		},
+		{
+			"Test an http status",
+			Wrap(statusError(409), "calling upstream"),
+			ClientFault,
+			true,
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FaultOf(tt.err); got != tt.want {
+				t.Errorf("FaultOf() = %v, want %v", got, tt.want)
+			}
+			if got := IsClientFault(tt.err); got != tt.client {
+				t.Errorf("IsClientFault() = %v, want %v", got, tt.client)
+			}
+			if got := IsServerFault(tt.err); got != tt.server {
+				t.Errorf("IsServerFault() = %v, want %v", got, tt.server)
+			}
+		})
+	}
+	if IsServerFault(nil) || IsClientFault(nil) {
+		t.Errorf("nil is nobody's fault")
+	}
+}
+
+func TestFaultForObservers(t *testing.T) {
+	var observed map[string]string
+	remove := Observe(func(ev Event, err *Error) {
+		if ev == Recovered {
+			observed = Tags(ev, err)
+		}
+	})
+	defer remove()
+
+	var got *Error
+	func() {
+		defer ErrorHandler(func(err *Error) { got = err })()
+		Check(New("bad request").WithFault(ClientFault))
+	}()
+	if _, ok := got.Fields()["fault"]; ok || FaultOf(got) != ClientFault {
+		t.Errorf("ErrorHandler() handed %v, want the fault left to FaultOf", got.Fields())
+	}
+	if observed["fault"] != "client" || observed["event"] != "recovered" {
+		t.Errorf("Tags() = %v, want the fault for the observer", observed)
+	}
+}
@@ -0,0 +1,161 @@
+package eros
+
+import (
+	"net"
+	"os"
+	"reflect"
+)
+
+// WithField - attaches a key/value pair to this link in the chain, e.g. the path of a
+// file or the id of a request. Setting a key again replaces it. This is nil safe
+func (e *Error) WithField(key string, value interface{}) *Error {
+	if e != nil {
+		if e.fields == nil {
+			e.fields = map[string]interface{}{}
+		}
+		e.fields[key] = value
+	}
+	return e
+}
+
+// WithFields - WithField for every key/value pair in fields. This is nil safe
+func (e *Error) WithFields(fields map[string]interface{}) *Error {
+	for k, v := range fields {
+		e = e.WithField(k, v)
+	}
+	return e
+}
+
+// WithStruct - attaches the fields of v (a struct or a pointer to one) tagged
+// `eros:"name"` as fields under their tag name, so a request or entity can enrich an
+// error without listing its fields at every wrap site. Untagged embedded structs are
+// searched too. This is nil safe
+func (e *Error) WithStruct(v interface{}) *Error {
+	return e.withStruct(reflect.ValueOf(v))
+}
+
+// withStruct - WithStruct, for the reflected value; the fields of an unexported
+// embedded struct can't be turned back into an interface{}, but their own exported
+// fields can still be read
+func (e *Error) withStruct(val reflect.Value) *Error {
+	for val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return e
+		}
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return e
+	}
+	typ := val.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		name, tagged := f.Tag.Lookup("eros")
+		switch {
+		case tagged && name != "" && name != "-" && val.Field(i).CanInterface():
+			e = e.WithField(name, val.Field(i).Interface())
+		case !tagged && f.Anonymous:
+			e = e.withStruct(val.Field(i))
+		}
+	}
+	return e
+}
+
+// Fields - a copy of the fields attached to this link only
+func (e *Error) Fields() map[string]interface{} {
+	if e == nil || len(e.fields) == 0 {
+		return nil
+	}
+	fields := make(map[string]interface{}, len(e.fields))
+	for k, v := range e.fields {
+		fields[k] = v
+	}
+	return fields
+}
+
+// Precedence - which link wins when the same field is set at several depths of a chain
+type Precedence int
+
+const (
+	// OutermostWins - the value closest to the caller wins, the default. Outer layers
+	// know more about what the operation was for
+	OutermostWins Precedence = iota
+	// InnermostWins - the value closest to the root cause wins. Inner layers know more
+	// about what actually happened
+	InnermostWins
+)
+
+// FieldPrecedence - sets the precedence AllFields merges with
+func FieldPrecedence(p Precedence) Setting {
+	return func(c *config) {
+		c.precedence = p
+	}
+}
+
+// AllFields - the fields of every link in the chain merged into one map, keys set at
+// several depths resolved by the FieldPrecedence setting
+func (e *Error) AllFields() map[string]interface{} {
+	return allFields(e)
+}
+
+// allFields - AllFields, for any error
+func allFields(err error) map[string]interface{} {
+	var layers []map[string]interface{}
+	walk(err, func(err error) bool {
+		if l := link(err); l != nil && len(l.fields) > 0 {
+			layers = append(layers, l.fields)
+		}
+		return true
+	})
+	if len(layers) == 0 {
+		return nil
+	}
+	fields := map[string]interface{}{}
+	for i := range layers {
+		// later layers overwrite earlier ones, so apply the winning end last
+		layer := layers[len(layers)-1-i]
+		if conf().precedence == InnermostWins {
+			layer = layers[i]
+		}
+		for k, v := range layer {
+			fields[k] = v
+		}
+	}
+	return fields
+}
+
+// FieldsAt - a copy of the fields of the link depth steps down the chain, in the order
+// Walk visits them, where 0 is this link. nil if there's no such link or it has no
+// fields
+func (e *Error) FieldsAt(depth int) map[string]interface{} {
+	var at error
+	walk(e, func(l error) bool {
+		if depth == 0 {
+			at = l
+			return false
+		}
+		depth--
+		return true
+	})
+	return link(at).Fields()
+}
+
+// liftFields - copies the operation metadata of the io errors we know about onto e as
+// fields, so it survives into structured output without parsing err's string
+func liftFields(e *Error, err error) *Error {
+	switch t := err.(type) {
+	case *os.PathError:
+		e.WithField("op", t.Op).WithField("path", t.Path)
+	case *os.LinkError:
+		e.WithField("op", t.Op).WithField("old_path", t.Old).WithField("new_path", t.New)
+	case *net.OpError:
+		e.WithField("op", t.Op).WithField("net", t.Net)
+		if t.Addr != nil {
+			e.WithField("addr", t.Addr.String())
+		}
+		if t.Source != nil {
+			e.WithField("source_addr", t.Source.String())
+		}
+	}
+	return e
+}
@@ -0,0 +1,122 @@
+package eros
+
+import (
+	"net"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestLiftFields(t *testing.T) {
+	_, openErr := os.Open("/opt/abc/baddir/file")
+	linkErr := os.Link("/opt/abc/baddir/old", "/opt/abc/baddir/new")
+	opErr := &net.OpError{Op: "dial", Net: "tcp", Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 443}, Err: os.ErrDeadlineExceeded}
+	tests := []struct {
+		name string
+		err  error
+		want map[string]interface{}
+	}{
+		{
+			"Test *os.PathError",
+			openErr,
+			map[string]interface{}{"op": "open", "path": "/opt/abc/baddir/file"},
+		},
+		{
+			"Test *os.LinkError",
+			linkErr,
+			map[string]interface{}{"op": "link", "old_path": "/opt/abc/baddir/old", "new_path": "/opt/abc/baddir/new"},
+		},
+		{
+			"Test *net.OpError",
+			opErr,
+			map[string]interface{}{"op": "dial", "net": "tcp", "addr": "10.0.0.1:443"},
+		},
+		{
+			"Test anything else has no fields",
+			New("plain"),
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Wrap(tt.err, "wrapped").Fields(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Wrap().Fields() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAllFields(t *testing.T) {
+	err := Wrap(errors.Wrap(New("inner").WithField("id", 1).WithField("table", "users"), "foreign"), "outer").
+		WithField("id", 2)
+	tests := []struct {
+		name       string
+		precedence Precedence
+		want       map[string]interface{}
+	}{
+		{
+			"Test outermost wins",
+			OutermostWins,
+			map[string]interface{}{"id": 2, "table": "users"},
+		},
+		{
+			"Test innermost wins",
+			InnermostWins,
+			map[string]interface{}{"id": 1, "table": "users"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Configure(FieldPrecedence(tt.precedence))
+			defer Configure(FieldPrecedence(OutermostWins))
+			if got := err.AllFields(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AllFields() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFieldsAt(t *testing.T) {
+	err := Wrap(errors.Wrap(New("inner").WithField("id", 1), "foreign"), "outer").WithField("id", 2)
+	want := []map[string]interface{}{{"id": 2}, nil, nil, {"id": 1}, nil}
+	for depth, w := range want {
+		if got := err.FieldsAt(depth); !reflect.DeepEqual(got, w) {
+			t.Errorf("FieldsAt(%d) = %v, want %v", depth, got, w)
+		}
+	}
+}
+
+func TestWithStruct(t *testing.T) {
+	type Tenant struct {
+		TenantID string `eros:"tenant_id"`
+	}
+	type request struct {
+		Tenant
+		UserID   int    `eros:"user_id"`
+		Password string `eros:"-"`
+		Path     string
+		trace    string `eros:"trace"`
+	}
+	req := &request{Tenant{"acme"}, 42, "hunter2", "/users", "t-1"}
+	want := map[string]interface{}{"tenant_id": "acme", "user_id": 42}
+	if got := New("failed").WithStruct(req).Fields(); !reflect.DeepEqual(got, want) {
+		t.Errorf("WithStruct() = %v, want %v", got, want)
+	}
+	type inner struct {
+		Region string `eros:"region"`
+	}
+	type job struct {
+		inner
+		B int `eros:"b"`
+	}
+	want = map[string]interface{}{"region": "eu", "b": 1}
+	if got := New("failed").WithStruct(job{inner{"eu"}, 1}).Fields(); !reflect.DeepEqual(got, want) {
+		t.Errorf("WithStruct() = %v, want %v from an unexported embedded struct", got, want)
+	}
+	var nilReq *request
+	if got := New("failed").WithStruct(nilReq).Fields(); got != nil {
+		t.Errorf("WithStruct(nil) = %v, want no fields", got)
+	}
+}
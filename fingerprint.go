@@ -0,0 +1,36 @@
+package eros
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+)
+
+// Fingerprint - a short, stable hash of the kind of failure err is; two errors that
+// failed the same way have the same fingerprint, whatever their ids or timestamps.
+// Every link in the chain contributes its type, code and message, so messages
+// carrying variable data (e.g. an id) fingerprint apart; interned ones (see Msg) by
+// their id. Empty for nil
+func Fingerprint(err error) string {
+	if err == nil {
+		return ""
+	}
+	h := fnv.New64a()
+	walk(err, func(err error) bool {
+		var msg string
+		switch e := link(err); {
+		case e == nil:
+			msg = message(err)
+		case e.msgID != 0:
+			msg = strconv.FormatUint(e.msgID, 16)
+		case e.args != nil:
+			msg = e.format
+		default:
+			// as created, templates may well render variable fields
+			msg = e.msg
+		}
+		fmt.Fprintf(h, "%T\x00%s\x00%s\x00", err, linkCode(err), msg)
+		return true
+	})
+	return fmt.Sprintf("%016x", h.Sum64())
+}
@@ -0,0 +1,33 @@
+package eros
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestFingerprint(t *testing.T) {
+	failure := func(msg string) error {
+		return Wrap(errors.Wrap(New(msg).WithCode("E1"), "querying"), "loading user")
+	}
+	tests := []struct {
+		name string
+		a, b error
+		same bool
+	}{
+		{"Test the same failure twice", failure("timeout"), failure("timeout"), true},
+		{"Test a different root message", failure("timeout"), failure("refused"), false},
+		{"Test a different code", New("x").WithCode("E1"), New("x").WithCode("E2"), false},
+		{"Test a different type", New("x"), errors.New("x"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if same := Fingerprint(tt.a) == Fingerprint(tt.b); same != tt.same {
+				t.Errorf("Fingerprint() same = %v, want %v", same, tt.same)
+			}
+		})
+	}
+	if Fingerprint(nil) != "" {
+		t.Errorf("Fingerprint(nil) should be empty")
+	}
+}
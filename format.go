@@ -0,0 +1,46 @@
+package eros
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Format - implement fmt.Formatter the way pkg/errors and xerrors do. %s and %v print
+// just the message of this link, %q quotes it, and %+v prints the whole chain a link
+// per line, followed by the one trace made of every stack captured along it, see
+// Trace
+func (e Error) Format(s fmt.State, verb rune) {
+	mark(e)
+	msg, _ := e.render()
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			io.WriteString(s, e.verbose())
+			return
+		}
+		io.WriteString(s, msg)
+	case 's':
+		io.WriteString(s, msg)
+	case 'q':
+		fmt.Fprintf(s, "%q", msg)
+	}
+}
+
+// verbose - the %+v rendering of the chain below and including e
+func (e Error) verbose() string {
+	var sb strings.Builder
+	first := true
+	walk(&e, func(err error) bool {
+		if !first {
+			sb.WriteString("\ncaused by: ")
+		}
+		first = false
+		sb.WriteString(linkLine(err))
+		return true
+	})
+	for _, f := range Trace(&e) {
+		fmt.Fprintf(&sb, "\n\t%s\n\t\t%s:%d", f.Function, f.File, f.Line)
+	}
+	return sb.String()
+}
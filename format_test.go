@@ -0,0 +1,36 @@
+package eros
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	err := Wrap(New("row missing").WithCode("TEST_MISSING"), "loading user").WithField("id", 7)
+	tests := []struct {
+		name   string
+		format string
+		want   string
+	}{
+		{"Test %s is the message", "%s", "loading user"},
+		{"Test %v is the message", "%v", "loading user"},
+		{"Test %q quotes the message", "%q", `"loading user"`},
+		{"Test %+v is the chain", "%+v", "loading user id=7\ncaused by: row missing [TEST_MISSING]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fmt.Sprintf(tt.format, err); got != tt.want {
+				t.Errorf("Sprintf(%s) = %q, want %q", tt.format, got, tt.want)
+			}
+		})
+	}
+
+	Configure(CaptureStacks(true))
+	defer Configure(CaptureStacks(false))
+	verbose := fmt.Sprintf("%+v", Wrap(io.EOF, "reading"))
+	if !strings.Contains(verbose, "caused by: EOF") || !strings.Contains(verbose, "eros.TestFormat\n\t\t") {
+		t.Errorf("expected %%+v to print the chain and its stack, got %q", verbose)
+	}
+}
@@ -0,0 +1,114 @@
+package eros
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Gather - runs every fn on a goroutine of its own and waits for them all. Errors
+// raised by Check within a fn are recovered and count as its failure. Workers that
+// failed the same way (Is equal, or the same Fingerprint), typically by hitting the
+// same broken dependency, are collapsed into one cause annotated with how many did
+// and which, so N workers don't make N near identical links. nil if none failed
+func Gather(fns ...func() error) *Error {
+	errs := make([]error, len(fns))
+	var wg sync.WaitGroup
+	for i, fn := range fns {
+		wg.Add(1)
+		go func(i int, fn func() error) {
+			defer wg.Done()
+			// not ErrorHandler, a report of what the worker handled isn't a failure
+			defer func() {
+				if r := raised(recover()); r != nil {
+					handleRecovered(r, func(err *Error) {
+						errs[i] = err
+					})
+				}
+			}()
+			errs[i] = fn()
+		}(i, fn)
+	}
+	wg.Wait()
+	return collapse(errs)
+}
+
+// PartialMap - fn applied to every element of in concurrently, for batches where
+// whatever can be processed should be and the rest reported. oks holds the values of
+// the elements that succeeded, in the order of in; errs chains every failure, keyed
+// by its index in the field "index". Errors raised by Check within fn count as
+// failures. errs is nil if nothing failed
+func PartialMap[T, U any](in []T, fn func(T) Result[U]) (oks []U, errs *Error) {
+	results := make([]Result[U], len(in))
+	var wg sync.WaitGroup
+	for i, v := range in {
+		wg.Add(1)
+		go func(i int, v T) {
+			defer wg.Done()
+			defer func() {
+				if r := raised(recover()); r != nil {
+					handleRecovered(r, func(err *Error) {
+						results[i].Error = err
+					})
+				}
+			}()
+			results[i] = fn(v)
+		}(i, v)
+	}
+	wg.Wait()
+
+	var failed []error
+	for i, r := range results {
+		if r.Error != nil {
+			failed = append(failed, Wrapf(r.Error, "element %d failed", i).WithField("index", i))
+			continue
+		}
+		oks = append(oks, r.Value)
+	}
+	if len(failed) > 0 {
+		errs = WrapAll(fmt.Sprintf("%d of %d elements failed", len(failed), len(in)), failed...)
+	}
+	return oks, errs
+}
+
+// collapsed - the workers which failed the same way, and the first of their errors
+type collapsed struct {
+	err         error
+	fingerprint string
+	workers     []int
+}
+
+// collapse - merges the errors of workers, indexed by worker, deduplicating those
+// which failed the same way. nil if all were nil
+func collapse(errs []error) *Error {
+	var groups []*collapsed
+	failed := 0
+	for worker, err := range errs {
+		if err == nil {
+			continue
+		}
+		failed++
+		fp := Fingerprint(err)
+		var group *collapsed
+		for _, g := range groups {
+			if g.fingerprint == fp || Is(g.err, err) || Is(err, g.err) {
+				group = g
+				break
+			}
+		}
+		if group == nil {
+			group = &collapsed{err: err, fingerprint: fp}
+			groups = append(groups, group)
+		}
+		group.workers = append(group.workers, worker)
+	}
+	if failed == 0 {
+		return nil
+	}
+	nodes := make([]error, len(groups))
+	for i, g := range groups {
+		nodes[i] = Wrapf(g.err, "%d workers failed this way", len(g.workers)).
+			WithField("count", len(g.workers)).
+			WithField("workers", g.workers)
+	}
+	return WrapAll(fmt.Sprintf("%d of %d workers failed", failed, len(errs)), nodes...)
+}
@@ -0,0 +1,82 @@
+package eros
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+var errDependencyDown = errors.New("dependency down")
+
+func TestGather(t *testing.T) {
+	down := func() error { return errDependencyDown }
+	checked := func() error {
+		Check(errDependencyDown)
+		return nil
+	}
+	other := func() error { return errors.New("bad row") }
+	ok := func() error { return nil }
+
+	if err := Gather(ok, ok); err != nil {
+		t.Errorf("Gather() = %v, want nil", err)
+	}
+
+	err := Gather(down, ok, checked, other, down)
+	if err == nil {
+		t.Fatal("Gather() expected an error")
+	}
+	if err.msg != "4 of 5 workers failed" {
+		t.Errorf("Gather() message = %q", err.msg)
+	}
+	workers := map[int][]int{}
+	walk(err, func(e error) bool {
+		if l := link(e); l != nil && l.fields["count"] != nil {
+			workers[l.fields["count"].(int)] = l.fields["workers"].([]int)
+		}
+		return true
+	})
+	want := map[int][]int{3: {0, 2, 4}, 1: {3}}
+	if !reflect.DeepEqual(workers, want) {
+		t.Errorf("Gather() collapsed workers = %v, want %v", workers, want)
+	}
+	if !Is(err, errDependencyDown) {
+		t.Errorf("Gather() = %v, want the dependency's error in the chain", err)
+	}
+}
+
+func TestPartialMap(t *testing.T) {
+	in := []int{1, 2, 3, 4, 5}
+	oks, errs := PartialMap(in, func(n int) Result[int] {
+		switch n {
+		case 2:
+			return Result[int]{Error: errDependencyDown}
+		case 4:
+			Check(errDependencyDown)
+		}
+		return Result[int]{Value: n * 10}
+	})
+	if !reflect.DeepEqual(oks, []int{10, 30, 50}) {
+		t.Errorf("PartialMap() oks = %v, want the successes in order", oks)
+	}
+	if errs == nil {
+		t.Fatal("PartialMap() expected the failures")
+	}
+	var indexes []int
+	walk(errs, func(e error) bool {
+		if l := link(e); l != nil && l.fields["index"] != nil {
+			indexes = append(indexes, l.fields["index"].(int))
+		}
+		return true
+	})
+	sort.Ints(indexes)
+	if !reflect.DeepEqual(indexes, []int{1, 3}) {
+		t.Errorf("PartialMap() failed indexes = %v, want [1 3]", indexes)
+	}
+
+	oks, errs = PartialMap([]int{1}, func(n int) Result[int] { return Result[int]{Value: n} })
+	if errs != nil || len(oks) != 1 {
+		t.Errorf("PartialMap() = %v, %v, want everything ok", oks, errs)
+	}
+}
@@ -0,0 +1,121 @@
+package eros
+
+import (
+	"context"
+	"sync"
+)
+
+// Go - runs fn on a goroutine of its own. ErrorHandler only recovers the goroutine
+// it's deferred on, so a failed Check in a bare goroutine takes the whole process
+// down; here it's recovered and handed to every one of handlers in turn. With no
+// handlers the error only reaches the observers
+func Go(fn func(), handlers ...Handler) {
+	go func() {
+		defer func() {
+			if r := raised(recover()); r != nil {
+				handleRecovered(r, func(err *Error) {
+					for _, h := range handlers {
+						h(err)
+					}
+				})
+			}
+		}()
+		fn()
+	}()
+}
+
+// Group - goroutines run with Go, waited for with Wait; errgroup for the Check flow.
+// The errors they return or raise with Check are collected, or handed to the
+// Group's handler as they happen. The zero value collects, has no limit and no
+// context
+type Group struct {
+	wg      sync.WaitGroup
+	handler Handler
+	cancel  context.CancelFunc
+	sem     chan struct{}
+	once    sync.Once
+	mu      sync.Mutex
+	errs    []error
+}
+
+// NewGroup - a Group handing every failure to handler as it happens, rather than
+// collecting them for Wait
+func NewGroup(handler Handler) *Group {
+	return &Group{handler: handler}
+}
+
+// WithContext - a Group, and a ctx derived from ctx which is cancelled the first time
+// a goroutine of the group fails, or once Wait returns
+func WithContext(ctx context.Context) (*Group, context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Group{cancel: cancel}, ctx
+}
+
+// SetLimit - at most n goroutines of the group run at once, Go blocks until one can.
+// A negative n is no limit. Only call it before the first Go
+func (g *Group) SetLimit(n int) {
+	if n < 0 {
+		g.sem = nil
+		return
+	}
+	g.sem = make(chan struct{}, n)
+}
+
+// Go - runs fn on a goroutine of its own as part of the group. The goroutines are
+// numbered in the order they're started, the workers of Wait
+func (g *Group) Go(fn func() error) {
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+	g.mu.Lock()
+	worker := len(g.errs)
+	g.errs = append(g.errs, nil)
+	g.mu.Unlock()
+	g.wg.Add(1)
+	go func() {
+		defer func() {
+			if g.sem != nil {
+				<-g.sem
+			}
+			g.wg.Done()
+		}()
+		defer func() {
+			if r := raised(recover()); r != nil {
+				handleRecovered(r, func(err *Error) {
+					g.fail(worker, err)
+				})
+			}
+		}()
+		if err := fn(); err != nil {
+			g.fail(worker, CastOrWrap(err))
+		}
+	}()
+}
+
+// Wait - waits for every goroutine of the group, and returns what failed merged as
+// Gather does; those which failed the same way are collapsed into one cause, with
+// how many did and which. nil if nothing did, or if the group has a handler
+func (g *Group) Wait() *Error {
+	g.wg.Wait()
+	if g.cancel != nil {
+		g.cancel()
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return collapse(g.errs)
+}
+
+// fail - collects err as worker's, or hands it to the handler, and cancels the
+// group's context
+func (g *Group) fail(worker int, err *Error) {
+	if g.cancel != nil {
+		g.once.Do(g.cancel)
+	}
+	if g.handler != nil {
+		g.handler(err)
+		return
+	}
+	g.mu.Lock()
+	g.errs[worker] = err
+	g.mu.Unlock()
+}
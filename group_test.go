@@ -0,0 +1,121 @@
+package eros
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestGo(t *testing.T) {
+	done := make(chan *Error, 2)
+	Go(func() {
+		Check(errStepFailed)
+	}, func(err *Error) { done <- err }, func(err *Error) { done <- err })
+	for i := 0; i < 2; i++ {
+		if err := <-done; !Is(err, errStepFailed) {
+			t.Errorf("Go() handed %v, want the raised error", err)
+		}
+	}
+}
+
+func TestGroup(t *testing.T) {
+	errReturned := errors.New("returned")
+	var g Group
+	g.Go(func() error { return nil })
+	g.Go(func() error { return errReturned })
+	g.Go(func() error {
+		Check(errStepFailed)
+		return nil
+	})
+	err := g.Wait()
+	if err == nil || !Is(err, errReturned) || !Is(err, errStepFailed) {
+		t.Errorf("Wait() = %v, want both failures", err)
+	}
+
+	var fanned Group
+	for i := 0; i < 3; i++ {
+		fanned.Go(func() error { return errors.New("dependency down") })
+	}
+	fanned.Go(func() error { return nil })
+	err = fanned.Wait()
+	if err == nil || err.msg != "3 of 4 workers failed" {
+		t.Fatalf("Wait() = %v, want the failures merged as Gather does", err)
+	}
+	var counts []interface{}
+	walk(err, func(e error) bool {
+		if l := link(e); l != nil && l.fields["count"] != nil {
+			counts = append(counts, l.fields["count"])
+		}
+		return true
+	})
+	if !reflect.DeepEqual(counts, []interface{}{3}) {
+		t.Errorf("Wait() collapsed counts = %v, want the three workers in one", counts)
+	}
+
+	var (
+		mu      sync.Mutex
+		handled []*Error
+	)
+	h := NewGroup(func(err *Error) {
+		mu.Lock()
+		handled = append(handled, err)
+		mu.Unlock()
+	})
+	h.Go(func() error { return errReturned })
+	if err := h.Wait(); err != nil || len(handled) != 1 {
+		t.Errorf("Wait() = %v with %d handled, want the failure handed to the handler", err, len(handled))
+	}
+
+	var empty Group
+	if err := empty.Wait(); err != nil {
+		t.Errorf("Wait() on an empty group = %v", err)
+	}
+}
+
+func TestGroupContext(t *testing.T) {
+	g, ctx := WithContext(context.Background())
+	g.Go(func() error { return errStepFailed })
+	g.Go(func() error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	err := g.Wait()
+	if !Is(err, errStepFailed) || !Is(err, context.Canceled) {
+		t.Errorf("Wait() = %v, want the failure and the cancellation it caused", err)
+	}
+}
+
+func TestGroupLimit(t *testing.T) {
+	var (
+		g       Group
+		mu      sync.Mutex
+		running int
+		most    int
+	)
+	g.SetLimit(2)
+	for i := 0; i < 10; i++ {
+		g.Go(func() error {
+			mu.Lock()
+			running++
+			if running > most {
+				most = running
+			}
+			mu.Unlock()
+			time.Sleep(time.Millisecond)
+			mu.Lock()
+			running--
+			mu.Unlock()
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		t.Errorf("Wait() = %v", err)
+	}
+	if most > 2 {
+		t.Errorf("expected at most 2 goroutines at once, saw %d", most)
+	}
+}
@@ -0,0 +1,61 @@
+package eros
+
+import (
+	"runtime"
+	"runtime/debug"
+	"strings"
+)
+
+// MemorySafety - the code of runtime panics HardenedHandler recognizes as memory
+// safety violations; bad indexes, nil maps and pointers, faulting addresses
+const MemorySafety = "MEMORY_SAFETY"
+
+// memorySafetyMessages - how the runtime describes the panics we code MemorySafety
+var memorySafetyMessages = []string{
+	"index out of range",
+	"slice bounds out of range",
+	"nil map",
+	"nil pointer dereference",
+	"invalid memory address",
+	"unexpected fault address",
+}
+
+// HardenedHandler - ErrorHandler for code close to unsafe or cgo glue. Runtime panics
+// which are memory safety violations are wrapped and coded MemorySafety, so they can
+// be alerted on apart from business errors. For as long as the deferring function
+// runs, memory faults at unexpected addresses panic (debug.SetPanicOnFault) rather
+// than crash the process, so they are recovered too. Any panic with an error is
+// recovered, whether or not StrictRecover is on; others carry on panicking. Forget
+// the trailing () and the goroutine is left panicking on faults for good, see Deferred
+//
+//	defer HardenedHandler(handler)()
+func HardenedHandler(handler Handler) Deferred {
+	prev, inv := debug.SetPanicOnFault(true), watch()
+	return func() {
+		inv.done()
+		debug.SetPanicOnFault(prev)
+		if r := raised(recover()); r != nil {
+			if rerr, ok := r.(runtime.Error); ok && isMemorySafety(rerr) {
+				handleError(Wrap(rerr, "memory safety violation").WithCode(MemorySafety), handler)
+				return
+			}
+			// contained even with StrictRecover on, that's what we're here for
+			if e, ok := unchecked(r).(error); ok {
+				handleError(CastOrWrap(e), handler)
+				return
+			}
+			panic(r)
+		}
+	}
+}
+
+// isMemorySafety - whether the runtime error is a memory safety violation
+func isMemorySafety(err runtime.Error) bool {
+	msg := err.Error()
+	for _, m := range memorySafetyMessages {
+		if strings.Contains(msg, m) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package eros
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestHardenedHandler(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func()
+		want string
+	}{
+		{
+			"Test a nil map write",
+			func() {
+				var m map[string]int
+				m["boom"] = 1
+			},
+			MemorySafety,
+		},
+		{
+			"Test an index out of range",
+			func() {
+				s := []int{}
+				i := 3
+				_ = s[i]
+			},
+			MemorySafety,
+		},
+		{
+			"Test a failed type assertion isn't memory safety",
+			func() {
+				var v interface{} = "eros"
+				_ = v.(int)
+			},
+			"",
+		},
+		{
+			"Test a business error keeps its code",
+			func() {
+				Check(New("no such user").WithCode("NOT_FOUND"))
+			},
+			"NOT_FOUND",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *Error
+			func() {
+				defer HardenedHandler(func(err *Error) {
+					got = err
+				})()
+				tt.fn()
+			}()
+			if got == nil || Code(got) != tt.want {
+				t.Errorf("HardenedHandler() code = %q, want %q", Code(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestHardenedHandlerStrict(t *testing.T) {
+	Configure(StrictRecover(true))
+	defer Configure(StrictRecover(false))
+
+	zero := 0
+	tests := []struct {
+		name string
+		fn   func()
+	}{
+		{
+			"Test an error panic is contained",
+			func() { panic(errors.New("boom")) },
+		},
+		{
+			"Test a divide by zero is contained",
+			func() { _ = 1 / zero },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *Error
+			func() {
+				defer HardenedHandler(func(err *Error) {
+					got = err
+				})()
+				tt.fn()
+			}()
+			if got == nil {
+				t.Errorf("HardenedHandler() let the panic through with StrictRecover on")
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package eros
+
+import (
+	"html/template"
+	"strings"
+)
+
+// htmlLink - one link of the chain as the HTML template sees it
+type htmlLink struct {
+	Message string
+	Code    string
+	Frames  Frames
+	Next    *htmlLink
+}
+
+// chainHTML - every link is an open <details> nested inside the one wrapping it, with
+// its frames folded away in a closed <details> of their own
+var chainHTML = template.Must(template.New("chain").Parse(
+	`{{define "link"}}<details open class="eros-link"><summary>{{.Message}}` +
+		`{{with .Code}} <code class="eros-code">{{.}}</code>{{end}}</summary>` +
+		`{{with .Frames}}<details class="eros-frames"><summary>{{len .}} frames</summary><ol>` +
+		`{{range .}}<li><code>{{.Function}}</code> {{.File}}:{{.Line}}</li>{{end}}</ol></details>{{end}}` +
+		`{{with .Next}}{{template "link" .}}{{end}}</details>{{end}}` +
+		`<div class="eros-chain">{{template "link" .}}</div>`))
+
+// ToHTML - renders err's chain as nested, collapsible HTML for debug pages and email
+// alerts. Each link shows its message and code; the outermost gets a folded list of
+// the frames of the chain's trace, see Trace. Everything is escaped
+func ToHTML(err error) template.HTML {
+	if err == nil {
+		return ""
+	}
+	var root, last *htmlLink
+	walk(err, func(l error) bool {
+		hl := &htmlLink{Message: message(l), Code: linkCode(l)}
+		if root == nil {
+			root, hl.Frames = hl, Trace(err)
+		} else {
+			last.Next = hl
+		}
+		last = hl
+		return true
+	})
+	var sb strings.Builder
+	if err := chainHTML.Execute(&sb, root); err != nil {
+		return template.HTML(template.HTMLEscapeString(err.Error()))
+	}
+	return template.HTML(sb.String())
+}
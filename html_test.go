@@ -0,0 +1,27 @@
+package eros
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestToHTML(t *testing.T) {
+	err := Wrap(fmt.Errorf("loading <config>: %w", kindError{"not found", "no such file"}), "startup failed")
+	got := string(ToHTML(err))
+	for _, want := range []string{
+		`<summary>startup failed</summary>`,
+		`<summary>loading &lt;config&gt;</summary>`,
+		`<summary>not found: no such file</summary>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("ToHTML() = %s, want it to contain %s", got, want)
+		}
+	}
+	if n := strings.Count(got, `<details open class="eros-link">`); n != 3 {
+		t.Errorf("ToHTML() rendered %d links, want 3", n)
+	}
+	if ToHTML(nil) != "" {
+		t.Errorf("ToHTML(nil) should be empty")
+	}
+}
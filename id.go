@@ -0,0 +1,74 @@
+package eros
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// idPrefix - random per process, so ids from different processes don't collide
+	idPrefix = func() string {
+		b := make([]byte, 6)
+		if _, err := rand.Read(b); err != nil {
+			return strconv.FormatInt(time.Now().UnixNano(), 36)
+		}
+		return hex.EncodeToString(b)
+	}()
+	idSeq uint64
+)
+
+// nextID - the default id source; a per process prefix and a sequence number
+func nextID() string {
+	return idPrefix + "-" + strconv.FormatUint(atomic.AddUint64(&idSeq, 1), 36)
+}
+
+// IDSource - sets the func giving every new error its id, e.g. a fixed sequence for
+// record/replay tests. nil restores the default, which is unique per process
+func IDSource(fn func() string) Setting {
+	return func(c *config) {
+		c.id = fn
+	}
+}
+
+// Clock - sets the func giving every new error its timestamp, e.g. a fixed time for
+// golden file tests. nil restores the default, time.Now
+func Clock(fn func() time.Time) Setting {
+	return func(c *config) {
+		c.now = fn
+	}
+}
+
+// stamp - gives e its id and timestamp from the configured sources
+func stamp(e *Error) *Error {
+	c := conf()
+	if c.id != nil {
+		e.id = c.id()
+	} else {
+		e.id = nextID()
+	}
+	if c.now != nil {
+		e.at = c.now()
+	} else {
+		e.at = time.Now()
+	}
+	return e
+}
+
+// ID - identifies this error, e.g. to find it in the logs from a user's report
+func (e *Error) ID() string {
+	if e == nil {
+		return ""
+	}
+	return e.id
+}
+
+// Time - when this error was created
+func (e *Error) Time() time.Time {
+	if e == nil {
+		return time.Time{}
+	}
+	return e.at
+}
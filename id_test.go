@@ -0,0 +1,35 @@
+package eros
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestIDSourceAndClock(t *testing.T) {
+	if a, b := New("a"), New("b"); a.ID() == "" || a.ID() == b.ID() {
+		t.Errorf("expected unique default ids, got %q and %q", a.ID(), b.ID())
+	}
+
+	replay := func() (ids []string, times []time.Time) {
+		n := 0
+		at := time.Date(2022, 4, 26, 0, 0, 0, 0, time.UTC)
+		Configure(
+			IDSource(func() string { n++; return fmt.Sprintf("err-%d", n) }),
+			Clock(func() time.Time { return at }),
+		)
+		defer Configure(IDSource(nil), Clock(nil))
+		for _, e := range []*Error{New("first"), Wrap(New("inner"), "outer")} {
+			ids, times = append(ids, e.ID()), append(times, e.Time())
+		}
+		return
+	}
+	ids1, times1 := replay()
+	ids2, times2 := replay()
+	if fmt.Sprint(ids1, times1) != fmt.Sprint(ids2, times2) {
+		t.Errorf("expected identical replays, got %v %v and %v %v", ids1, times1, ids2, times2)
+	}
+	if ids1[1] != "err-3" {
+		t.Errorf("expected the outer error to be the third made, got %q", ids1[1])
+	}
+}
@@ -0,0 +1,62 @@
+package eros
+
+import (
+	"hash/fnv"
+	"sync"
+)
+
+// Message - an interned message, see Msg
+type Message struct {
+	id   uint64
+	text string
+}
+
+var (
+	messagesMu sync.RWMutex
+	messages   = map[string]Message{}
+)
+
+// Msg - interns text as a message for NewMsg and WrapMsg. Every error made from it
+// shares the one string, however many millions of identical wraps a long lived error
+// buffer holds, and is fingerprinted by the message's id rather than its text. Meant
+// for package level vars;
+//
+//	var msgLoad = eros.Msg("failed to load config")
+func Msg(text string) Message {
+	messagesMu.RLock()
+	m, ok := messages[text]
+	messagesMu.RUnlock()
+	if ok {
+		return m
+	}
+	messagesMu.Lock()
+	defer messagesMu.Unlock()
+	if m, ok = messages[text]; !ok {
+		// a hash rather than a sequence, the id must be the same in every process
+		h := fnv.New64a()
+		h.Write([]byte(text))
+		m = Message{id: h.Sum64(), text: text}
+		messages[text] = m
+	}
+	return m
+}
+
+// String - implement the Stringer interface
+func (m Message) String() string {
+	return m.text
+}
+
+// NewMsg - New, with an interned message
+func NewMsg(m Message) *Error {
+	e := newError(m.text, 1)
+	e.msgID = m.id
+	return e
+}
+
+// WrapMsg - Wrap, with an interned message
+func WrapMsg(err error, m Message) *Error {
+	e := wrap(err, m.text, 1)
+	e.msgID = m.id
+	countError(e)
+	return e
+}
@@ -0,0 +1,28 @@
+package eros
+
+import (
+	"io"
+	"testing"
+)
+
+func TestMsg(t *testing.T) {
+	a := Msg("failed to " + "load")
+	b := Msg("failed to load")
+	if a != b || a.String() != "failed to load" {
+		t.Errorf("Msg() = %v and %v, want the same message", a, b)
+	}
+	if Msg("failed to save") == a {
+		t.Errorf("Msg() of a different text should be a different message")
+	}
+
+	x, y := WrapMsg(io.EOF, a), WrapMsg(io.EOF, a)
+	if x.msgID != a.id || x.msg != a.text {
+		t.Errorf("expected the wrap to carry the interned message")
+	}
+	if Fingerprint(x) != Fingerprint(y) || Fingerprint(x) == Fingerprint(Wrap(io.EOF, "failed to save")) {
+		t.Errorf("expected interned messages to fingerprint by message")
+	}
+	if n := NewMsg(b); n.msg != "failed to load" || n.msgID == 0 {
+		t.Errorf("NewMsg() = %v", n)
+	}
+}
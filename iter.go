@@ -0,0 +1,49 @@
+//go:build go1.23
+
+package eros
+
+import "iter"
+
+// All - every error in the chain, in the order Walk visits them;
+//
+//	for err := range e.All() {
+//		...
+//	}
+//
+// This is nil safe
+func (e *Error) All() iter.Seq[error] {
+	return e.Walk
+}
+
+// Chain - every error in err's chain, err first. Links of ours, errors wrapped with
+// fmt.Errorf's %w and the errors of a multi error (e.g. errors.Join) are all
+// followed, see Walk. Empty for nil
+func Chain(err error) iter.Seq[error] {
+	return func(yield func(error) bool) {
+		if err != nil {
+			walk(err, yield)
+		}
+	}
+}
+
+// Find - every error of type T in err's chain, in the order Walk visits them. Like
+// As, a pointer in the chain also matches the type it points to
+func Find[T error](err error) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		walk(err, func(l error) bool {
+			t, ok := linkAs[T](l)
+			return !ok || yield(t)
+		})
+	}
+}
+
+// All - every frame, innermost first
+func (f Frames) All() iter.Seq[Frame] {
+	return func(yield func(Frame) bool) {
+		for _, frame := range f {
+			if !yield(frame) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,74 @@
+//go:build go1.23
+
+package eros
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+)
+
+func TestChain(t *testing.T) {
+	joined := errors.Join(io.EOF, io.ErrUnexpectedEOF)
+	err := Wrap(fmt.Errorf("reading: %w", joined), "import failed")
+
+	var got []error
+	for link := range Chain(err) {
+		got = append(got, link)
+	}
+	if len(got) != 5 || got[3] != io.EOF || got[4] != io.ErrUnexpectedEOF {
+		t.Errorf("Chain() = %v, want every link including both joined errors", got)
+	}
+
+	n := 0
+	for range err.All() {
+		if n++; n == 2 {
+			break
+		}
+	}
+	if n != 2 {
+		t.Errorf("expected ranging over All() to stop at break")
+	}
+
+	for range Chain(nil) {
+		t.Errorf("expected nothing in the chain of nil")
+	}
+	var nilErr *Error
+	for range nilErr.All() {
+		t.Errorf("expected nothing in the chain of a nil Error")
+	}
+}
+
+func TestFind(t *testing.T) {
+	err := Join(
+		kindError{"quota", "disk full"},
+		Wrap(kindError{"auth", "token expired"}, "refreshing"),
+		io.EOF,
+	)
+	var kinds []string
+	for k := range Find[kindError](err) {
+		kinds = append(kinds, k.kind)
+	}
+	if len(kinds) != 2 || kinds[0] != "quota" || kinds[1] != "auth" {
+		t.Errorf("Find() = %v, want both kindErrors in order", kinds)
+	}
+	for range Find[*os.PathError](err) {
+		t.Errorf("Find() yielded a type not in the chain")
+	}
+}
+
+func TestFramesAll(t *testing.T) {
+	frames := WithStack(io.EOF).Frames()
+	n := 0
+	for f := range frames.All() {
+		if f != frames[n] {
+			t.Errorf("All() yielded %v at %d, want %v", f, n, frames[n])
+		}
+		n++
+	}
+	if n != len(frames) {
+		t.Errorf("All() yielded %d frames, want %d", n, len(frames))
+	}
+}
@@ -0,0 +1,40 @@
+package eros
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Join - aggregates independent failures, none the cause of another, into one error.
+// Nils are dropped and nil is returned if all were nil. The failures sit below the
+// returned link in a node implementing Unwrap() []error, so the stdlib's errors.Is
+// and errors.As (go1.20+) find them as well as ours do
+func Join(errs ...error) *Error {
+	var joined multiError
+	for _, err := range errs {
+		if err != nil {
+			joined = append(joined, err)
+		}
+	}
+	if len(joined) == 0 {
+		return nil
+	}
+	return wrap(joined, fmt.Sprintf("%d errors", len(joined)), 1)
+}
+
+// multiError - independent errors, as Join and the stdlib's errors.Join aggregate them
+type multiError []error
+
+// Error - implement the error interface, a line per error
+func (m multiError) Error() string {
+	msgs := make([]string, len(m))
+	for i, err := range m {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap - the errors, for errors.Is and errors.As
+func (m multiError) Unwrap() []error {
+	return m
+}
@@ -0,0 +1,30 @@
+//go:build go1.20
+
+package eros
+
+import (
+	"errors"
+	"io"
+	"os"
+	"testing"
+)
+
+func TestJoinStdlib(t *testing.T) {
+	_, openErr := os.Open("/opt/abc/baddir/file")
+	ours := Join(io.EOF, openErr)
+	if !errors.Is(ours, io.EOF) || !errors.Is(ours, os.ErrNotExist) {
+		t.Errorf("errors.Is() should find the failures of Join")
+	}
+	var pathErr *os.PathError
+	if !errors.As(ours, &pathErr) {
+		t.Errorf("errors.As() should find the *os.PathError of Join")
+	}
+
+	theirs := Wrap(errors.Join(io.EOF, openErr), "cleanup")
+	if !Is(theirs, io.EOF) || !Is(theirs, os.ErrNotExist) {
+		t.Errorf("Is() should find the failures of errors.Join")
+	}
+	if !As(theirs, &pathErr) {
+		t.Errorf("As() should find the *os.PathError of errors.Join")
+	}
+}
@@ -0,0 +1,25 @@
+package eros
+
+import (
+	"io"
+	"os"
+	"testing"
+)
+
+func TestJoin(t *testing.T) {
+	if Join(nil, nil) != nil {
+		t.Errorf("Join() of nils should be nil")
+	}
+	_, openErr := os.Open("/opt/abc/baddir/file")
+	err := Join(io.EOF, nil, Wrap(openErr, "opening"))
+	if err.msg != "2 errors" {
+		t.Errorf("Join() message = %q", err.msg)
+	}
+	if !Is(err, io.EOF) || !Is(err, os.ErrNotExist) {
+		t.Errorf("Join() = %v, want both failures found by Is", err)
+	}
+	var pathErr *os.PathError
+	if !As(err, &pathErr) {
+		t.Errorf("Join() = %v, want the *os.PathError found by As", err)
+	}
+}
@@ -0,0 +1,97 @@
+package eros
+
+import (
+	"encoding/json"
+
+	"github.com/dawenga/eros/eroswire"
+)
+
+// MarshalJSON - implement json.Marshaler. The whole chain is encoded, next and cause
+// nested as objects of their own, so it can be shipped across a service boundary or
+// kept in an audit log and brought back with UnmarshalJSON. Errors in the chain that
+// aren't ours are encoded by their message and code
+func (e Error) MarshalJSON() ([]byte, error) {
+	mark(e)
+	return json.Marshal(ToWire(&e))
+}
+
+// UnmarshalJSON - implement json.Unmarshaler, the inverse of MarshalJSON. Every link
+// comes back as an eros Error with its message, count, code, fields, id, time,
+// severity, HTTP status and retryable, timeout and temporary flags; numbers in fields
+// come back as float64, as encoding/json has it. A link standing for a sentinel is
+// matched by Is again if this process has a sentinel by that name (see Sentinel).
+// Errors that weren't ours are only their message now, Is and As won't find them
+func (e *Error) UnmarshalJSON(b []byte) error {
+	var w eroswire.WireError
+	if err := json.Unmarshal(b, &w); err != nil {
+		return err
+	}
+	*e = *FromWire(&w)
+	return nil
+}
+
+// ToWire - err and the chain below it in the wire format, see eroswire. Errors in the
+// chain that aren't ours are only their message and code. nil for nil
+func ToWire(err error) *eroswire.WireError {
+	if err == nil {
+		return nil
+	}
+	e := link(err)
+	if e == nil {
+		return &eroswire.WireError{Message: message(err), Code: linkCode(err), Cause: ToWire(Unwrap(err))}
+	}
+	j := &eroswire.WireError{
+		Message:   e.text(),
+		Count:     e.count,
+		Code:      e.code,
+		Fields:    e.fields,
+		ID:        e.id,
+		Severity:  int(e.severity),
+		Status:    e.status,
+		Retryable: e.retryable,
+		Timeout:   e.timeout.wire(),
+		Temporary: e.temporary.wire(),
+		Sentinel:  e.sentinel,
+	}
+	if !e.at.IsZero() {
+		j.Time = &e.at
+	}
+	if e.next != nil {
+		j.Next = ToWire(e.next)
+	}
+	j.Cause = ToWire(e.cause)
+	return j
+}
+
+// FromWire - the chain j encodes in the wire format, see eroswire. Every link is an
+// eros Error. nil for nil
+func FromWire(j *eroswire.WireError) *Error {
+	if j == nil {
+		return nil
+	}
+	e := &Error{
+		msg:       j.Message,
+		count:     j.Count,
+		code:      j.Code,
+		fields:    j.Fields,
+		id:        j.ID,
+		severity:  Severity(j.Severity),
+		status:    j.Status,
+		retryable: j.Retryable,
+		timeout:   flagFromWire(j.Timeout),
+		temporary: flagFromWire(j.Temporary),
+	}
+	if s, ok := LookupSentinel(j.Sentinel); ok {
+		e.sentinel = s.sentinel
+	}
+	if j.Time != nil {
+		e.at = *j.Time
+	}
+	if j.Next != nil {
+		e.next = FromWire(j.Next)
+	}
+	if j.Cause != nil {
+		e.cause = FromWire(j.Cause)
+	}
+	return e
+}
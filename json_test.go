@@ -0,0 +1,96 @@
+package eros
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os/exec"
+	"testing"
+
+	"github.com/dawenga/eros/eroswire"
+)
+
+func TestJSON(t *testing.T) {
+	inner := New("no such user").WithCode(NotFound).WithField("user", "bob").WithSeverity(Warning)
+	err := Wrap(io.EOF, "reading").WithCause(inner).WithField("request", "r-1")
+
+	b, merr := json.Marshal(err)
+	if merr != nil {
+		t.Fatal(merr)
+	}
+	var got Error
+	if uerr := json.Unmarshal(b, &got); uerr != nil {
+		t.Fatal(uerr)
+	}
+	var want, have bytes.Buffer
+	WriteChain(&want, err)
+	WriteChain(&have, &got)
+	if want.String() != have.String() {
+		t.Errorf("round trip = %q, want %q", have.String(), want.String())
+	}
+	if got.ID() != err.ID() || !got.Time().Equal(err.Time()) || got.Count() != err.Count() {
+		t.Errorf("round trip lost the id, time or count")
+	}
+	if got.Severity() != Warning || Code(&got) != NotFound {
+		t.Errorf("round trip lost the severity or code")
+	}
+	if got.cause == nil || message(got.cause) != "EOF" {
+		t.Errorf("round trip lost the cause behind next, got %s", b)
+	}
+
+	if uerr := json.Unmarshal([]byte(`{"message":`), &got); uerr == nil {
+		t.Errorf("expected an error decoding garbage")
+	}
+}
+
+func TestFromExitErrorJSON(t *testing.T) {
+	b, _ := json.Marshal(Wrap(New("no such file").WithCode(NotFound), "loading config"))
+	_, err := exec.Command("sh", "-c", "echo '"+string(b)+"' >&2; exit 2").Output()
+	got := FromExitError(err)
+	if message(got) != "loading config" || Code(got) != NotFound {
+		t.Errorf("FromExitError() = %v, want the child's chain", got)
+	}
+	var ee *exec.ExitError
+	if !As(got, &ee) {
+		t.Errorf("FromExitError() lost the *exec.ExitError")
+	}
+}
+
+func TestWire(t *testing.T) {
+	if ToWire(nil) != nil || FromWire(nil) != nil {
+		t.Errorf("expected nil to stay nil both ways")
+	}
+
+	// as a service without eros would produce it
+	w := &eroswire.WireError{
+		Message: "loading user",
+		Status:  503,
+		Cause:   &eroswire.WireError{Message: "db down", Code: Unavailable, Severity: int(Fatal)},
+	}
+	err := FromWire(w)
+	if HTTPStatus(err) != 503 || Code(err) != Unavailable || err.Severity() != Fatal {
+		t.Errorf("FromWire() = %v, want the status, code and severity kept", err)
+	}
+
+	back := ToWire(err)
+	if back.Message != "loading user" || back.Cause == nil || back.Cause.Code != Unavailable {
+		t.Errorf("ToWire() = %+v, want the chain as it came", back)
+	}
+	flagged := FromWire(ToWire(New("deadline exceeded").WithTimeout(true).WithTemporary(false)))
+	if !IsTimeout(flagged) || IsTemporary(flagged) || flagged.temporary != no {
+		t.Errorf("FromWire(ToWire()) = %v, want the timeout and temporary flags kept", flagged)
+	}
+	if w := ToWire(New("plain")); w.Timeout != nil || w.Temporary != nil {
+		t.Errorf("ToWire() = %+v, want unset flags left out", w)
+	}
+	errWired := Sentinel("wired")
+	var decoded Error
+	b, _ := json.Marshal(WrapSentinel(errWired, io.EOF))
+	if err := json.Unmarshal(b, &decoded); err != nil || !Is(&decoded, errWired) {
+		t.Errorf("UnmarshalJSON() = %v, %v, want the sentinel matched again", &decoded, err)
+	}
+	unknown := FromWire(&eroswire.WireError{Message: "gone", Sentinel: "never made"})
+	if unknown.sentinel != "" {
+		t.Errorf("FromWire() kept sentinel %q, want one this process doesn't know dropped", unknown.sentinel)
+	}
+}
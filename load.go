@@ -0,0 +1,234 @@
+package eros
+
+import (
+	"encoding/json"
+	stdflag "flag"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Source - somewhere configuration values come from, see Load
+type Source interface {
+	// Name - where the values come from, for errors
+	Name() string
+	// Values - the values by key; an error if they couldn't be read
+	Values() (map[string]string, error)
+}
+
+// Load - a T populated from sources, later sources overriding earlier ones, e.g. a
+// file, then the environment, then flags. Each exported field tagged `config:"key"`
+// is set from key, or from its `default:"..."` tag when no source has it; with
+// `config:"key,required"` it must be set by one. Strings, bools, numbers,
+// time.Duration and []string (comma separated) are understood. Start up being the
+// most error dense part of a process, every failure is reported at once; a source
+// that couldn't be read, and each key missing or not parsing, keyed by the field
+// "field" (see Validation). T must be a struct
+//
+//	type settings struct {
+//		Addr    string        `config:"addr" default:":8080"`
+//		DB      string        `config:"db_url,required"`
+//		Timeout time.Duration `config:"timeout" default:"5s"`
+//	}
+//	cfg := eros.Load[settings](eros.FileSource("app.json"), eros.EnvSource("APP_")).Check()
+func Load[T any](sources ...Source) Result[T] {
+	var cfg T
+	rv := reflect.ValueOf(&cfg).Elem()
+	if rv.Kind() != reflect.Struct {
+		return Result[T]{Error: Newf("failed to load config into %T, not a struct", cfg)}
+	}
+	var failed []error
+	values := map[string]string{}
+	for _, src := range sources {
+		vals, err := src.Values()
+		if err != nil {
+			failed = append(failed, Wrapf(err, "failed to read config from %s", src.Name()))
+			continue
+		}
+		for k, v := range vals {
+			values[k] = v
+		}
+	}
+	var v Validation
+	for i := 0; i < rv.NumField(); i++ {
+		f := rv.Type().Field(i)
+		tag, ok := f.Tag.Lookup("config")
+		if !ok || f.PkgPath != "" {
+			continue
+		}
+		opts := strings.Split(tag, ",")
+		key := opts[0]
+		val, ok := values[key]
+		if !ok {
+			val, ok = f.Tag.Lookup("default")
+		}
+		if !ok {
+			for _, opt := range opts[1:] {
+				if opt == "required" {
+					v.Add(key, "required", "must be set")
+				}
+			}
+			continue
+		}
+		if err := setConfig(rv.Field(i), val); err != nil {
+			v.Add(key, "type", err.Error())
+		}
+	}
+	if verr := v.Err(); verr != nil {
+		failed = append(failed, verr)
+	}
+	if err := WrapAll("failed to load config", failed...); err != nil {
+		return Result[T]{Error: err}
+	}
+	return Result[T]{Value: cfg}
+}
+
+// durationType - the type of a time.Duration, an int64 parsed otherwise
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// setConfig - sets field to s, parsed as its type needs
+func setConfig(field reflect.Value, s string) error {
+	switch {
+	case field.Type() == durationType:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return fmt.Errorf("not a duration: %q", s)
+		}
+		field.SetInt(int64(d))
+		return nil
+	case field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.String:
+		var parts []string
+		for _, p := range strings.Split(s, ",") {
+			if p = strings.TrimSpace(p); p != "" {
+				parts = append(parts, p)
+			}
+		}
+		field.Set(reflect.ValueOf(parts).Convert(field.Type()))
+		return nil
+	}
+	var err error
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(s)
+	case reflect.Bool:
+		var b bool
+		if b, err = strconv.ParseBool(s); err == nil {
+			field.SetBool(b)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n int64
+		if n, err = strconv.ParseInt(s, 10, field.Type().Bits()); err == nil {
+			field.SetInt(n)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var n uint64
+		if n, err = strconv.ParseUint(s, 10, field.Type().Bits()); err == nil {
+			field.SetUint(n)
+		}
+	case reflect.Float32, reflect.Float64:
+		var n float64
+		if n, err = strconv.ParseFloat(s, field.Type().Bits()); err == nil {
+			field.SetFloat(n)
+		}
+	default:
+		return fmt.Errorf("%s can't be configured", field.Type())
+	}
+	if err != nil {
+		return fmt.Errorf("not a valid %s: %q", field.Type(), s)
+	}
+	return nil
+}
+
+// envSource - a Source of the environment
+type envSource struct {
+	prefix string
+}
+
+// EnvSource - the environment variables whose names start with prefix, keyed by the
+// rest of the name in lower case; with prefix "APP_", APP_DB_URL is db_url
+func EnvSource(prefix string) Source {
+	return envSource{prefix: prefix}
+}
+
+// Name - implement Source
+func (s envSource) Name() string {
+	return "the environment"
+}
+
+// Values - implement Source
+func (s envSource) Values() (map[string]string, error) {
+	vals := map[string]string{}
+	for _, kv := range os.Environ() {
+		name, val, _ := strings.Cut(kv, "=")
+		if strings.HasPrefix(name, s.prefix) {
+			vals[strings.ToLower(strings.TrimPrefix(name, s.prefix))] = val
+		}
+	}
+	return vals, nil
+}
+
+// fileSource - a Source of a JSON file
+type fileSource struct {
+	path string
+}
+
+// FileSource - the JSON object in the file at path, keyed by its members. Values
+// which aren't strings are taken as their JSON, e.g. 8080 or true
+func FileSource(path string) Source {
+	return fileSource{path: path}
+}
+
+// Name - implement Source
+func (s fileSource) Name() string {
+	return s.path
+}
+
+// Values - implement Source
+func (s fileSource) Values() (map[string]string, error) {
+	b, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, err
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, err
+	}
+	vals := make(map[string]string, len(raw))
+	for k, v := range raw {
+		var str string
+		if json.Unmarshal(v, &str) == nil {
+			vals[k] = str
+		} else {
+			vals[k] = string(v)
+		}
+	}
+	return vals, nil
+}
+
+// flagSource - a Source of command line flags
+type flagSource struct {
+	fs *stdflag.FlagSet
+}
+
+// FlagSource - the flags of fs which were set, keyed by their names with dashes as
+// underscores. fs must have been parsed
+func FlagSource(fs *stdflag.FlagSet) Source {
+	return flagSource{fs: fs}
+}
+
+// Name - implement Source
+func (s flagSource) Name() string {
+	return "the flags of " + s.fs.Name()
+}
+
+// Values - implement Source
+func (s flagSource) Values() (map[string]string, error) {
+	vals := map[string]string{}
+	s.fs.Visit(func(f *stdflag.Flag) {
+		vals[strings.ReplaceAll(f.Name, "-", "_")] = f.Value.String()
+	})
+	return vals, nil
+}
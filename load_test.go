@@ -0,0 +1,57 @@
+package eros
+
+import (
+	stdflag "flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type loadSettings struct {
+	Addr    string        `config:"addr" default:":8080"`
+	DB      string        `config:"db_url,required"`
+	Workers int           `config:"workers"`
+	Timeout time.Duration `config:"timeout" default:"5s"`
+	Debug   bool          `config:"debug"`
+	Hosts   []string      `config:"hosts"`
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.json")
+	if err := os.WriteFile(path, []byte(`{"db_url": "postgres://db", "workers": 4, "hosts": "a, b"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("EROSTEST_WORKERS", "8")
+	fs := stdflag.NewFlagSet("app", stdflag.ContinueOnError)
+	fs.Bool("debug", false, "")
+	if err := fs.Parse([]string{"-debug"}); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load[loadSettings](FileSource(path), EnvSource("EROSTEST_"), FlagSource(fs)).Get()
+	if err != nil {
+		t.Fatalf("Load() failed; %v", err)
+	}
+	want := loadSettings{Addr: ":8080", DB: "postgres://db", Workers: 8, Timeout: 5 * time.Second, Debug: true, Hosts: []string{"a", "b"}}
+	if cfg.Addr != want.Addr || cfg.DB != want.DB || cfg.Workers != want.Workers || cfg.Timeout != want.Timeout ||
+		cfg.Debug != want.Debug || len(cfg.Hosts) != 2 || cfg.Hosts[1] != "b" {
+		t.Errorf("Load() = %+v, want %+v", cfg, want)
+	}
+
+	t.Setenv("EROSTEST_TIMEOUT", "soon")
+	_, err = Load[loadSettings](FileSource(filepath.Join(t.TempDir(), "missing.json")), EnvSource("EROSTEST_")).Get()
+	failed := map[string]string{}
+	walk(err, func(l error) bool {
+		if f, ok := l.(FieldError); ok {
+			failed[f.Field] = f.Rule
+		}
+		return true
+	})
+	if failed["db_url"] != "required" || failed["timeout"] != "type" || len(failed) != 2 {
+		t.Errorf("Load() failed with %v, want every bad key", failed)
+	}
+	if !Is(err, os.ErrNotExist) {
+		t.Errorf("Load() = %v, want the missing file reported too", err)
+	}
+}
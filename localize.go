@@ -0,0 +1,86 @@
+package eros
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+var (
+	translationsMu sync.RWMutex
+	// translations - the templates of each code, by language
+	translations = map[string]map[string]string{}
+)
+
+// RegisterTranslation - sets the template the message of a link coded code is
+// rendered from in lang (e.g. "de" or "pt-BR"), see Localize. The template is a format
+// given the args of the link, see NewT; explicit argument indexes let a language take
+// them in its own order, "Tarif %[2]s: Benutzer %[1]d hat kein Kontingent mehr". An
+// empty template removes the translation
+func RegisterTranslation(code, lang, template string) {
+	translationsMu.Lock()
+	defer translationsMu.Unlock()
+	if template == "" {
+		delete(translations[code], lang)
+		return
+	}
+	if translations[code] == nil {
+		translations[code] = map[string]string{}
+	}
+	translations[code][lang] = template
+}
+
+// translation - the template of code in lang, or in its base language ("de" for
+// "de-CH") when there's none for the region
+func translation(code, lang string) (string, bool) {
+	if code == "" {
+		return "", false
+	}
+	translationsMu.RLock()
+	defer translationsMu.RUnlock()
+	if t, ok := translations[code][lang]; ok {
+		return t, true
+	}
+	if base, _, ok := strings.Cut(lang, "-"); ok {
+		t, ok := translations[code][base]
+		return t, ok
+	}
+	return "", false
+}
+
+// Localize - the whole chain rendered in lang for whoever is on the other end, the
+// messages of its links outermost first and separated by ": ". Links coded with a
+// translation in lang (see RegisterTranslation) are rendered from it and their args,
+// the rest are as Error() would render them; so are coded links not made by NewT when
+// the translation takes args. This is nil safe
+func (e *Error) Localize(lang string) string {
+	if e == nil {
+		return ""
+	}
+	var msgs []string
+	walk(e, func(l error) bool {
+		msgs = append(msgs, localizeLink(l, lang))
+		return true
+	})
+	return strings.Join(msgs, ": ")
+}
+
+// localizeLink - the message of this one link in lang, see Localize
+func localizeLink(l error, lang string) string {
+	t, ok := translation(linkCode(l), lang)
+	if !ok {
+		return message(l)
+	}
+	e := link(l)
+	if e != nil {
+		mark(e)
+	}
+	if e != nil && e.args != nil {
+		return fmt.Sprintf(t, e.args...)
+	}
+	if strings.Contains(strings.ReplaceAll(t, "%%", ""), "%") {
+		// no args to fill its verbs with
+		return message(l)
+	}
+	return strings.ReplaceAll(t, "%%", "%")
+}
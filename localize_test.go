@@ -0,0 +1,27 @@
+package eros
+
+import "testing"
+
+func TestLocalize(t *testing.T) {
+	RegisterTranslation("EROS_TEST_NO_QUOTA", "de", "Tarif %[2]s: Benutzer %[1]d hat kein Kontingent mehr")
+	RegisterTranslation(NotFound, "de", "nicht gefunden")
+	defer RegisterTranslation("EROS_TEST_NO_QUOTA", "de", "")
+	defer RegisterTranslation(NotFound, "de", "")
+
+	err := Wrap(NewT("user %d has no quota left on plan %s", 7, "free").WithCode("EROS_TEST_NO_QUOTA"), "billing failed").
+		WithCause(New("no plan").WithCode(NotFound))
+	if got := err.Localize("de-CH"); got != "billing failed: nicht gefunden: Tarif free: Benutzer 7 hat kein Kontingent mehr" {
+		t.Errorf("Localize() = %q, want the coded links translated", got)
+	}
+	if got := err.Localize("fr"); got != "billing failed: no plan: user 7 has no quota left on plan free" {
+		t.Errorf("Localize() = %q, want the messages without a translation", got)
+	}
+	plain := New("no quota left").WithCode("EROS_TEST_NO_QUOTA")
+	if got := plain.Localize("de"); got != "no quota left" {
+		t.Errorf("Localize() = %q, want the message of a link without args kept", got)
+	}
+	var nilErr *Error
+	if nilErr.Localize("de") != "" {
+		t.Errorf("expected nothing for nil")
+	}
+}
@@ -0,0 +1,64 @@
+package eros
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Metrics - counts errors for a metrics system, e.g. a Prometheus counter vector
+// labelled by code and severity. See CountErrors
+type Metrics interface {
+	// IncError - one more error with code (empty if it has none) and severity, see
+	// Severity.String
+	IncError(code, severity string)
+}
+
+// CountErrors - sets the Metrics counting errors; every Check failure, every Wrap (and
+// Wrapf, WrapCode and the like) and every error an ErrorHandler recovers is counted
+// once, by the code and severity of the chain once made. The cast of what Check is
+// given isn't a Wrap of its own, so an error raised by Check and recovered counts
+// twice, once for each. nil, the default, counts nothing
+func CountErrors(m Metrics) Setting {
+	return func(c *config) {
+		c.metrics = m
+	}
+}
+
+// countError - counts err, if there's anything to count it
+func countError(err *Error) {
+	if m := conf().metrics; m != nil {
+		m.IncError(Code(err), err.Severity().String())
+	}
+}
+
+// MetricKey - what Counters counts errors by
+type MetricKey struct {
+	Code     string
+	Severity string
+}
+
+// Counters - Metrics kept in memory, for tests and for exposing by hand. The zero
+// value is ready to use
+type Counters struct {
+	counts sync.Map
+}
+
+// IncError - implement Metrics
+func (c *Counters) IncError(code, severity string) {
+	key := MetricKey{code, severity}
+	n, ok := c.counts.Load(key)
+	if !ok {
+		n, _ = c.counts.LoadOrStore(key, new(uint64))
+	}
+	atomic.AddUint64(n.(*uint64), 1)
+}
+
+// Snapshot - the counts so far
+func (c *Counters) Snapshot() map[MetricKey]uint64 {
+	res := map[MetricKey]uint64{}
+	c.counts.Range(func(key, n interface{}) bool {
+		res[key.(MetricKey)] = atomic.LoadUint64(n.(*uint64))
+		return true
+	})
+	return res
+}
@@ -0,0 +1,47 @@
+package eros
+
+import (
+	"io"
+	"testing"
+)
+
+func TestCountErrors(t *testing.T) {
+	c := &Counters{}
+	Configure(CountErrors(c))
+	defer Configure(CountErrors(nil))
+
+	_ = Wrap(New("no such user").WithCode(NotFound), "loading user")
+	func() {
+		defer ErrorHandler(func(*Error) {})()
+		Check(New("db down").WithSeverity(Fatal))
+	}()
+	func() {
+		defer ErrorHandler(func(*Error) {})()
+		Check(io.EOF)
+	}()
+	_ = WrapCode(io.EOF, AlreadyExists)
+	_ = Wrap(io.EOF, "reading", WithCodeOpt(Unavailable))
+
+	got := c.Snapshot()
+	want := map[MetricKey]uint64{
+		{NotFound, "warning"}:      1,
+		{"", "fatal"}:              2,
+		{"", "error"}:              2,
+		{AlreadyExists, "warning"}: 1,
+		{Unavailable, "error"}:     1,
+	}
+	if len(got) != len(want) {
+		t.Errorf("Snapshot() = %v, want %v", got, want)
+	}
+	for key, n := range want {
+		if got[key] != n {
+			t.Errorf("Snapshot()[%v] = %d, want %d", key, got[key], n)
+		}
+	}
+
+	Configure(CountErrors(nil))
+	_ = Wrap(io.EOF, "not counted")
+	if after := c.Snapshot(); len(after) != len(got) {
+		t.Errorf("expected nothing counted once unset, got %v", after)
+	}
+}
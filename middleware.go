@@ -0,0 +1,28 @@
+package eros
+
+import "net/http"
+
+// Middleware - next, with any panic in it recovered into an error response, so
+// handlers can Check their way through a request end to end. A panic that isn't an
+// error is recovered too, as Protect does; bar http.ErrAbortHandler, which is how a
+// handler asks net/http to abort the response. The error goes to every reporter, with
+// the request's context, then it's written as problem details with the status it
+// maps to, see WriteProblem. If next already wrote part of its response there's no
+// taking that back, the problem just follows it
+func Middleware(next http.Handler, reporters ...HandlerCtx) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			if rec := raised(recover()); rec != nil {
+				if rec == http.ErrAbortHandler {
+					panic(rec)
+				}
+				err := protected(rec)
+				for _, report := range reporters {
+					report(r.Context(), err)
+				}
+				WriteProblem(w, err)
+			}
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
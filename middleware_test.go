@@ -0,0 +1,58 @@
+package eros
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMiddleware(t *testing.T) {
+	var reported []*Error
+	report := func(ctx context.Context, err *Error) {
+		reported = append(reported, err)
+	}
+	h := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/missing":
+			Check(New("no such user").WithCode(NotFound))
+		case "/index":
+			var users []string
+			_, _ = w.Write([]byte(users[1]))
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}), report)
+
+	tests := []struct {
+		path   string
+		status int
+	}{
+		{"/ok", http.StatusNoContent},
+		{"/missing", http.StatusNotFound},
+		{"/index", http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest("GET", tt.path, nil))
+			if rec.Code != tt.status {
+				t.Errorf("status = %d, want %d", rec.Code, tt.status)
+			}
+			if tt.status != http.StatusNoContent && rec.Header().Get("Content-Type") != ProblemContentType {
+				t.Errorf("expected a problem+json body, got %q", rec.Header().Get("Content-Type"))
+			}
+		})
+	}
+	if len(reported) != 2 {
+		t.Errorf("expected both failures reported, got %v", reported)
+	}
+
+	defer func() {
+		if r := recover(); r != http.ErrAbortHandler {
+			t.Errorf("expected http.ErrAbortHandler to carry on, got %v", r)
+		}
+	}()
+	Middleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic(http.ErrAbortHandler)
+	})).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+}
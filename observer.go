@@ -0,0 +1,136 @@
+package eros
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// Event - what just happened to the error an Observer is handed
+type Event int
+
+const (
+	// Recovered - ErrorHandler recovered the error from a panic
+	Recovered Event = iota
+	// Unhandled - the error was garbage collected without ever being handled, see
+	// DebugUnhandled. Observers are notified from the runtime's finalizer goroutine
+	Unhandled
+	// Raised - a Check (or one of its variants) failed and is about to panic with the
+	// error. If it's recovered, observers hear about it again as Recovered
+	Raised
+	// Misused - the func ErrorHandler returned was never invoked, see Deferred. Only
+	// reported with DebugUnhandled on, from the runtime's finalizer goroutine
+	Misused
+	// Warned - a CheckWarn failed, and the caller carried on regardless
+	Warned
+	// Reported - the error was handed to Report explicitly
+	Reported
+)
+
+// String - implement the Stringer interface
+func (ev Event) String() string {
+	switch ev {
+	case Recovered:
+		return "recovered"
+	case Unhandled:
+		return "unhandled"
+	case Raised:
+		return "raised"
+	case Misused:
+		return "misused"
+	case Warned:
+		return "warned"
+	case Reported:
+		return "reported"
+	}
+	return "unknown"
+}
+
+// Observer - is handed errors as they pass through eros on the user's behalf. This is
+// the place for logging, metrics and alerting that shouldn't live in every handler;
+// see Tags for the labels to put on them
+type Observer func(ev Event, err *Error)
+
+// Tags - short values to label err with as ev happens to it, for an observer's metrics
+// or logs; "event", "fault" (see FaultOf, so availability can leave out client
+// faults) and "code" unless there's none. err itself isn't changed
+func Tags(ev Event, err *Error) map[string]string {
+	tags := map[string]string{
+		"event": ev.String(),
+		"fault": FaultOf(err).String(),
+	}
+	if code := Code(err); code != "" {
+		tags["code"] = code
+	}
+	return tags
+}
+
+// observer - a registered Observer, the pointer gives us identity for removal
+type observer struct {
+	fn Observer
+}
+
+var (
+	// observersMu - serialises changes to the list, notify doesn't need it
+	observersMu sync.Mutex
+	// observers - the []*observer registered, swapped for a changed copy on change
+	observers atomic.Value
+)
+
+// Observe - registers an observer and returns a func which removes it again. Safe to
+// call at any time, errors being notified meanwhile go to the observers as they were
+func Observe(fn Observer) (remove func()) {
+	o := &observer{fn}
+	observersMu.Lock()
+	list := registered()
+	observers.Store(append(list[:len(list):len(list)], o))
+	observersMu.Unlock()
+	return func() {
+		observersMu.Lock()
+		defer observersMu.Unlock()
+		list := registered()
+		for i, v := range list {
+			if v == o {
+				observers.Store(append(list[:i:i], list[i+1:]...))
+				return
+			}
+		}
+	}
+}
+
+// registered - the observers currently registered
+func registered() []*observer {
+	list, _ := observers.Load().([]*observer)
+	return list
+}
+
+// notify - hand err to every registered observer, unless it's suppressed
+func notify(ev Event, err *Error) {
+	if IsSuppressed(err) {
+		return
+	}
+	for _, o := range registered() {
+		o.fn(ev, err)
+	}
+}
+
+// Goroutines - the dump of all goroutines taken when this error was recovered, nil
+// unless CaptureGoroutines is on and the error was Fatal
+func (e *Error) Goroutines() []byte {
+	if e == nil {
+		return nil
+	}
+	return e.goroutines
+}
+
+// dumpGoroutines - the stacks of every goroutine, growing the buffer until they fit
+func dumpGoroutines() []byte {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return buf[:n]
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
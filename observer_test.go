@@ -0,0 +1,42 @@
+package eros
+
+import (
+	"bytes"
+	"testing"
+)
+
+// recoverWith - panics with err under an ErrorHandler and returns what the handler got
+func recoverWith(err error) (got *Error) {
+	defer ErrorHandler(func(e *Error) {
+		got = e
+	})()
+	panic(err)
+}
+
+func TestObserveGoroutines(t *testing.T) {
+	var events []Event
+	remove := Observe(func(ev Event, err *Error) {
+		events = append(events, ev)
+	})
+	defer remove()
+
+	Configure(CaptureGoroutines(true))
+	defer Configure(CaptureGoroutines(false))
+
+	if got := recoverWith(New("ordinary")); got.Goroutines() != nil {
+		t.Errorf("expected no goroutine dump for a Normal error")
+	}
+	got := recoverWith(Wrap(New("deadlocked").WithSeverity(Fatal), "giving up"))
+	if !bytes.Contains(got.Goroutines(), []byte("goroutine ")) {
+		t.Errorf("expected a goroutine dump for a Fatal error, got %q", got.Goroutines())
+	}
+	if len(events) != 2 || events[0] != Recovered {
+		t.Errorf("expected 2 Recovered events, got %v", events)
+	}
+
+	remove()
+	recoverWith(New("unobserved"))
+	if len(events) != 2 {
+		t.Errorf("expected no events after removal, got %v", events)
+	}
+}
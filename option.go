@@ -0,0 +1,66 @@
+package eros
+
+// Option - a value which may not be there, the (T, bool) counterpart of Result, so
+// APIs returning (T, ok) can take part in the Check/Handle flow
+type Option[T any] struct {
+	value T
+	ok    bool
+}
+
+// Some - an Option holding v
+func Some[T any](v T) Option[T] {
+	return Option[T]{value: v, ok: true}
+}
+
+// None - an empty Option
+func None[T any]() Option[T] {
+	return Option[T]{}
+}
+
+// OptionOf - an Option from the (T, ok) an API returned, e.g. a map lookup
+func OptionOf[T any](v T, ok bool) Option[T] {
+	if !ok {
+		return None[T]()
+	}
+	return Some(v)
+}
+
+// Get - the value and whether there is one
+func (o Option[T]) Get() (T, bool) {
+	return o.value, o.ok
+}
+
+// IsSome - whether there is a value
+func (o Option[T]) IsSome() bool {
+	return o.ok
+}
+
+// IsNone - whether there isn't a value
+func (o Option[T]) IsNone() bool {
+	return !o.ok
+}
+
+// OkOr - a Result with the value, or failing with err if there is none
+func (o Option[T]) OkOr(err error) Result[T] {
+	if !o.ok {
+		return Result[T]{Error: err}
+	}
+	return Result[T]{Value: o.value}
+}
+
+// Ok - an Option with r's value, None if r failed. The error is dropped
+func (r Result[T]) Ok() Option[T] {
+	if r.Error != nil {
+		return None[T]()
+	}
+	return Some(r.Value)
+}
+
+// CheckSome - the value of o, otherwise invokes the error handler with msg
+func CheckSome[T any](o Option[T], msg string) T {
+	if !o.ok {
+		countCheck(1)
+		raise(New(msg))
+	}
+	return o.value
+}
@@ -0,0 +1,45 @@
+package eros
+
+import (
+	"testing"
+)
+
+func TestOption(t *testing.T) {
+	users := map[string]int{"bob": 7}
+	bob, ok := users["bob"]
+	some := OptionOf(bob, ok)
+	alice, ok := users["alice"]
+	none := OptionOf(alice, ok)
+	if v, ok := some.Get(); !ok || v != 7 || !some.IsSome() {
+		t.Errorf("OptionOf() = %v, %v, want Some(7)", v, ok)
+	}
+	if !none.IsNone() || None[int]().IsSome() {
+		t.Errorf("expected a missing key to be None")
+	}
+
+	if r := some.OkOr(errStepFailed); r.Error != nil || r.Value != 7 {
+		t.Errorf("OkOr() = %v, want ok", r)
+	}
+	if r := none.OkOr(errStepFailed); r.Error != errStepFailed {
+		t.Errorf("OkOr() = %v, want the error", r)
+	}
+	if o := (Result[int]{Value: 3}).Ok(); !o.IsSome() {
+		t.Errorf("Ok() of a success should be Some")
+	}
+	if o := (Result[int]{Value: 3, Error: errStepFailed}).Ok(); !o.IsNone() {
+		t.Errorf("Ok() of a failure should be None")
+	}
+
+	if CheckSome(some, "no bob") != 7 {
+		t.Errorf("CheckSome() should return the value")
+	}
+	var got *Error
+	func() {
+		defer ErrorHandler(func(err *Error) { got = err })()
+		CheckSome(none, "no alice")
+		t.Errorf("CheckSome() should not return on None")
+	}()
+	if got == nil || got.msg != "no alice" {
+		t.Errorf("CheckSome() raised %v", got)
+	}
+}
@@ -0,0 +1,94 @@
+package eros
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+/*
+	Panic free mode is for environments where panic/recover is too slow or doesn't
+	behave (WASM, tinygo, latency critical paths). A failing Check stores its error in
+	a slot of the calling goroutine and returns instead of panicking; the deferred
+	funcs of ErrorHandler and friends take it from there, so the same code works in
+	either mode. Execution carries on past a failed Check though, so code meant to run
+	in both should bail out when Pending says so.
+*/
+
+// PanicFree - when on, Check and friends hand their error to the goroutine's
+// ErrorHandler through a slot rather than a panic, see Pending. The slot is cleared
+// when the ErrorHandler takes the error; one raised on a goroutine with no ErrorHandler
+// deferred stays there, so at most 10000 are kept and errors raised past that only
+// reach the observers
+func PanicFree(on bool) Setting {
+	return func(c *config) {
+		c.panicFree = on
+	}
+}
+
+var (
+	// slots - the error raised on each goroutine and not yet handled, by goroutine id
+	slots sync.Map
+	// slotsUsed - how many slots hold an error
+	slotsUsed int64
+	// maxSlots - how many slots may hold an error at once. A goroutine raising without
+	// an ErrorHandler deferred never has its slot cleared, so they're bounded
+	maxSlots int64 = 10000
+)
+
+// Pending - the error raised on this goroutine in panic free mode and not yet handled,
+// nil if there is none. The first error raised is kept, later ones are dropped
+//
+//	eros.Check(err)
+//	if eros.Pending() != nil {
+//		return
+//	}
+func Pending() *Error {
+	if !conf().panicFree {
+		return nil
+	}
+	if e, ok := slots.Load(goid()); ok {
+		return e.(*Error)
+	}
+	return nil
+}
+
+// store - keeps err for this goroutine's ErrorHandler, unless it already has one. Past
+// maxSlots it's dropped, observers have heard of it as Raised all the same
+func store(err *Error) {
+	if atomic.AddInt64(&slotsUsed, 1) > maxSlots {
+		atomic.AddInt64(&slotsUsed, -1)
+		return
+	}
+	if _, loaded := slots.LoadOrStore(goid(), err); loaded {
+		atomic.AddInt64(&slotsUsed, -1)
+	}
+}
+
+// raised - r if a panic was recovered, otherwise the error raised on this goroutine
+// in panic free mode, if any; the slot is cleared. Outside panic free mode this is r,
+// no goroutine id is looked up on the way out of every ErrorHandler
+func raised(r interface{}) interface{} {
+	if r != nil || !conf().panicFree {
+		return r
+	}
+	if e, ok := slots.LoadAndDelete(goid()); ok {
+		atomic.AddInt64(&slotsUsed, -1)
+		return checkPanic{e.(*Error)}
+	}
+	return nil
+}
+
+// goid - the id of the calling goroutine, which the runtime only tells in a trace
+func goid() uint64 {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+	b = bytes.TrimPrefix(b, []byte("goroutine "))
+	if i := bytes.IndexByte(b, ' '); i > 0 {
+		b = b[:i]
+	}
+	id, _ := strconv.ParseUint(string(b), 10, 64)
+	return id
+}
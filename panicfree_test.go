@@ -0,0 +1,91 @@
+package eros
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+var errPanicFree = errors.New("no panic")
+
+func TestPanicFree(t *testing.T) {
+	Configure(PanicFree(true))
+	defer Configure(PanicFree(false))
+
+	var handled []*Error
+	carriedOn := false
+	func() {
+		defer ErrorHandler(func(err *Error) {
+			handled = append(handled, err)
+		})()
+		Check(errPanicFree)
+		if Pending() == nil {
+			t.Errorf("expected the error to be pending")
+		}
+		CheckVal(0, errors.New("dropped, one is pending"))
+		carriedOn = true
+	}()
+	if !carriedOn {
+		t.Errorf("expected execution to carry on past a failed Check")
+	}
+	if len(handled) != 1 || !Is(handled[0], errPanicFree) {
+		t.Errorf("expected the first error handed to ErrorHandler, got %v", handled)
+	}
+	if Pending() != nil {
+		t.Errorf("expected ErrorHandler to clear the slot")
+	}
+
+	done := make(chan *Error)
+	go func() {
+		deferFn, recovered := ErrorHandled(func(err *Error) {
+			done <- err
+		})
+		defer func() {
+			if !*recovered {
+				done <- nil
+			}
+		}()
+		defer deferFn()
+		Check(errPanicFree)
+	}()
+	if err := <-done; !Is(err, errPanicFree) {
+		t.Errorf("expected the goroutine's own error, got %v", err)
+	}
+
+	called := false
+	func() {
+		defer ErrorHandler(func(*Error) { called = true })()
+	}()
+	if called {
+		t.Errorf("expected nothing handled when nothing was raised")
+	}
+}
+
+func TestPanicFreeSlotsBounded(t *testing.T) {
+	Configure(PanicFree(true))
+	defer Configure(PanicFree(false))
+	defer func(n int64) { maxSlots = n }(maxSlots)
+	maxSlots = atomic.LoadInt64(&slotsUsed) + 2
+
+	// raised with no ErrorHandler, nothing clears these slots
+	var ids []uint64
+	for i := 0; i < 3; i++ {
+		done := make(chan uint64)
+		go func() {
+			Check(errPanicFree)
+			done <- goid()
+		}()
+		ids = append(ids, <-done)
+	}
+	kept := 0
+	for _, id := range ids {
+		if _, ok := slots.LoadAndDelete(id); ok {
+			atomic.AddInt64(&slotsUsed, -1)
+			kept++
+		}
+	}
+	if kept != 2 {
+		t.Errorf("expected the slots bounded at 2, %d were kept", kept)
+	}
+}
@@ -0,0 +1,66 @@
+package eros
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// ProblemContentType - the media type of a Problem, per RFC 7807
+const ProblemContentType = "application/problem+json"
+
+// Problem - an error as RFC 7807 problem details, for the body of a REST API's error
+// responses
+type Problem struct {
+	// Type - a URI reference identifying the kind of problem, about:blank when the
+	// status says all there is to say
+	Type string `json:"type"`
+	// Title - a short summary of the kind of problem
+	Title string `json:"title"`
+	// Status - the HTTP status of the response
+	Status int `json:"status"`
+	// Detail - what went wrong this time, see PublicMessage
+	Detail string `json:"detail,omitempty"`
+	// Instance - a URI reference identifying this occurrence, the id of the error
+	Instance string `json:"instance,omitempty"`
+	// Code - the error's code, an extension member
+	Code string `json:"code,omitempty"`
+}
+
+// ProblemDetails - err as problem details. The status is HTTPStatus(err). A code makes
+// the type (as the URN "urn:eros:code:" followed by the code) and, when registered
+// with a message, the title; otherwise the type is about:blank and the title the
+// status text. Only the public message is given as the detail, see PublicMessage, and
+// the code only if it's public, see Sanitize. nil for nil
+func ProblemDetails(err error) *Problem {
+	if err == nil {
+		return nil
+	}
+	p := &Problem{
+		Type:   "about:blank",
+		Status: HTTPStatus(err),
+		Detail: PublicMessage(err),
+	}
+	p.Title = http.StatusText(p.Status)
+	if code := Code(err); isPublicCode(code) {
+		p.Type, p.Code = "urn:eros:code:"+code, code
+		if info, _ := LookupCode(code); info.Message != "" {
+			p.Title = info.Message
+		}
+	}
+	if e := link(err); e != nil && e.id != "" {
+		p.Instance = "urn:eros:error:" + e.id
+	}
+	return p
+}
+
+// WriteProblem - writes err to w as problem details, with their content type and
+// status. Nothing is written for nil
+func WriteProblem(w http.ResponseWriter, err error) {
+	p := ProblemDetails(err)
+	if p == nil {
+		return
+	}
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(p.Status)
+	_ = json.NewEncoder(w).Encode(p)
+}
@@ -0,0 +1,47 @@
+package eros
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestProblemDetails(t *testing.T) {
+	if ProblemDetails(nil) != nil {
+		t.Errorf("expected no problem for nil")
+	}
+
+	p := ProblemDetails(Wrap(New("no row for id 7").WithCode(NotFound), "loading user"))
+	if p.Type != "urn:eros:code:NOT_FOUND" || p.Title != "not found" || p.Status != 404 || p.Code != NotFound {
+		t.Errorf("ProblemDetails() = %+v, want the problem of the NotFound code", p)
+	}
+	if p.Detail != genericPublicMessage {
+		t.Errorf("expected messages made without a template kept out of the detail, got %q", p.Detail)
+	}
+
+	p = ProblemDetails(New("teapot").WithCode("BREWING").WithHTTPStatus(418))
+	if p.Type != "about:blank" || p.Title != "I'm a teapot" || p.Status != 418 || p.Code != "" {
+		t.Errorf("ProblemDetails() = %+v, want a blank type and an internal code left out", p)
+	}
+}
+
+func TestWriteProblem(t *testing.T) {
+	err := New("no such user").WithCode(NotFound)
+	rec := httptest.NewRecorder()
+	WriteProblem(rec, err)
+	if ct := rec.Header().Get("Content-Type"); ct != ProblemContentType {
+		t.Errorf("Content-Type = %q, want %q", ct, ProblemContentType)
+	}
+	if rec.Code != 404 {
+		t.Errorf("status = %d, want 404", rec.Code)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	for _, member := range []string{"type", "title", "status", "detail", "instance"} {
+		if _, ok := got[member]; !ok {
+			t.Errorf("expected the %q member in %s", member, rec.Body)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package eros
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// Protect - runs fn, recovering any panic at all, not just what a Check raised, e.g. an
+// index out of range. A panic that isn't an error is turned into one carrying the
+// panic's value in the field "panic". Unless the chain has one already, the stack the
+// panic unwound from is attached. ErrorHandler lets a panic that isn't an error carry
+// on, which on a goroutine of its own takes the process down; this doesn't. nil if fn
+// completes
+func Protect(fn func()) (res *Error) {
+	defer func() {
+		if r := raised(recover()); r != nil {
+			res = protected(r)
+		}
+	}()
+	fn()
+	return nil
+}
+
+// ProtectVal - Protect, for a fn returning a value
+func ProtectVal[T any](fn func() T) (res Result[T]) {
+	defer func() {
+		if r := raised(recover()); r != nil {
+			res.Error = protected(r)
+		}
+	}()
+	res.Value = fn()
+	return
+}
+
+// Rethrow - raises err again, as Check would have in the first place, for an error
+// that was contained (by Protect, Gather, a Group...) and which a supervisor decides
+// to escalate after all through the nearest ErrorHandler. err is raised as is; the
+// stack it was captured with, its id, time and the rest of its chain go with it, no
+// new stack is taken here. Nothing is raised for nil
+func Rethrow(err *Error) {
+	if err != nil {
+		raise(err)
+	}
+}
+
+// protected - the recovered panic r as an error, handled as ErrorHandler would
+func protected(r interface{}) (res *Error) {
+	var err *Error
+	if e, ok := unchecked(r).(error); ok {
+		err = CastOrWrap(e)
+	} else {
+		err = New(fmt.Sprintf("panic: %v", r)).WithField("panic", r)
+	}
+	if !hasStack(err) {
+		err.stack = panicStack()
+	}
+	handleError(err, func(e *Error) {
+		res = e
+	})
+	return res
+}
+
+// panicStack - the stack a deferred func is recovering a panic from, starting at the
+// frame that panicked rather than in the runtime's panic machinery
+func panicStack() []uintptr {
+	// skip panicStack, protected and the deferred func
+	pcs := callers(3)
+	for len(pcs) > 0 {
+		fn := runtime.FuncForPC(pcs[0] - 1)
+		if fn == nil || !strings.HasPrefix(fn.Name(), "runtime.") {
+			break
+		}
+		pcs = pcs[1:]
+	}
+	return pcs
+}
@@ -0,0 +1,67 @@
+package eros
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestProtect(t *testing.T) {
+	if err := Protect(func() {}); err != nil {
+		t.Errorf("Protect() = %v, want nil", err)
+	}
+
+	if err := Protect(func() { Check(errStepFailed) }); !Is(err, errStepFailed) {
+		t.Errorf("Protect() = %v, want the raised error", err)
+	}
+
+	err := Protect(func() { panic("boom") })
+	if err == nil || err.Fields()["panic"] != "boom" {
+		t.Fatalf("Protect() = %v, want the panic value as a field", err)
+	}
+
+	err = Protect(func() {
+		var s []int
+		_ = s[1]
+	})
+	var rerr runtime.Error
+	if !As(err, &rerr) {
+		t.Fatalf("Protect() = %v, want the runtime.Error in the chain", err)
+	}
+	frames := err.StackTrace()
+	if len(frames) == 0 || !strings.Contains(frames[0].Function, "TestProtect") {
+		t.Errorf("expected the stack the panic unwound from, got %v", frames)
+	}
+}
+
+func TestProtectVal(t *testing.T) {
+	res := ProtectVal(func() int { return 1 })
+	if res.Error != nil || res.Value != 1 {
+		t.Errorf("ProtectVal() = %v, %v, want 1, nil", res.Value, res.Error)
+	}
+	res = ProtectVal(func() int { panic(42) })
+	if res.Error == nil || allFields(res.Error)["panic"] != 42 {
+		t.Errorf("ProtectVal() = %v, want the panic value as a field", res.Error)
+	}
+}
+
+func TestRethrow(t *testing.T) {
+	contained := Protect(func() {
+		var m map[string]int
+		m["boom"]++
+	})
+	frames, id := contained.StackTrace(), contained.ID()
+
+	var got *Error
+	func() {
+		defer ErrorHandler(func(err *Error) { got = err })()
+		Rethrow(nil)
+		Rethrow(contained)
+	}()
+	if got == nil || got.ID() != id {
+		t.Fatalf("Rethrow() raised %v, want the contained error itself", got)
+	}
+	if again := got.StackTrace(); len(again) != len(frames) || again[0] != frames[0] {
+		t.Errorf("expected the original stack kept, got %v", again)
+	}
+}
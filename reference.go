@@ -0,0 +1,42 @@
+package eros
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+)
+
+// referenceField - the field a reference is kept in
+const referenceField = "reference"
+
+// WithReference - gives the chain a short reference, which PublicMessage (and so
+// Sanitize and ProblemDetails) includes in what the public sees, while the full
+// chain observers and logs see carries it as the field "reference". A user quoting
+// it to support leads straight to the detailed error. A chain which already has one
+// keeps it. This is nil safe
+func (e *Error) WithReference() *Error {
+	if e != nil && Reference(e) == "" {
+		e.WithField(referenceField, newReference())
+	}
+	return e
+}
+
+// Reference - the reference given to err's chain by WithReference, empty if none was
+func Reference(err error) string {
+	ref := ""
+	walk(err, func(l error) bool {
+		if e := link(l); e != nil {
+			ref, _ = e.fields[referenceField].(string)
+		}
+		return ref == ""
+	})
+	return ref
+}
+
+// newReference - a short random reference, easily read out over the phone
+func newReference() string {
+	b := make([]byte, 5)
+	if _, err := rand.Read(b); err != nil {
+		return nextID()
+	}
+	return base32.StdEncoding.EncodeToString(b)
+}
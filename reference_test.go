@@ -0,0 +1,36 @@
+package eros
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWithReference(t *testing.T) {
+	err := Wrap(New("pq: relation \"users\" does not exist"), "loading user").WithReference()
+	ref := Reference(err)
+	if len(ref) != 8 {
+		t.Fatalf("Reference() = %q, want a short reference", ref)
+	}
+	if again := err.WithReference(); Reference(again) != ref {
+		t.Errorf("expected a chain with a reference to keep it")
+	}
+
+	public := Sanitize(err, PublicProfile)
+	if want := genericPublicMessage + " (reference " + ref + ")"; public.msg != want {
+		t.Errorf("public message = %q, want %q", public.msg, want)
+	}
+	if strings.Contains(public.msg, "users") {
+		t.Errorf("expected nothing internal in the public message")
+	}
+	if err.AllFields()[referenceField] != ref {
+		t.Errorf("expected the full chain to carry the reference as a field")
+	}
+
+	if Reference(New("no reference")) != "" || Reference(nil) != "" {
+		t.Errorf("expected no reference where none was given")
+	}
+	var nilErr *Error
+	if nilErr.WithReference() != nil {
+		t.Errorf("expected WithReference to be nil safe")
+	}
+}
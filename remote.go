@@ -0,0 +1,124 @@
+package eros
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// maxRemoteBody - the most of an error response's body read to map it
+const maxRemoteBody = 64 << 10
+
+var (
+	remoteMu      sync.RWMutex
+	remoteMappers []func(status int, body []byte) *Error
+)
+
+// MapRemote - registers mapper to translate the error responses of the services we
+// call, by their status and body, into coded chains; see FromResponse. A service
+// declares once how to read the error bodies of those it calls (a Python service's
+// {"detail": ...}, a Java one's {"error": ..., "path": ...}) rather than every call
+// site doing so. The most recently registered mapper is tried first, the first chain
+// one returns is used; nil leaves the response to the next
+func MapRemote(mapper func(status int, body []byte) *Error) {
+	remoteMu.Lock()
+	defer remoteMu.Unlock()
+	remoteMappers = append(remoteMappers, mapper)
+}
+
+// FromResponse - the chain an error response of another service maps to, nil if the
+// response didn't fail (a status below 400). The body is read for the mappers (see
+// MapRemote), closing it is left to the caller. With no mapper for it, problem details
+// (see Problem) give the message and code, otherwise the status does, coded by the
+// taxonomy. Either way the status is in the field "remote_status"
+func FromResponse(resp *http.Response) *Error {
+	if resp == nil || resp.StatusCode < 400 {
+		return nil
+	}
+	var body []byte
+	if resp.Body != nil {
+		body, _ = io.ReadAll(io.LimitReader(resp.Body, maxRemoteBody))
+	}
+	err := mapRemote(resp.StatusCode, body)
+	if err == nil {
+		err = remoteDefault(resp.StatusCode, body)
+	}
+	return err.WithField("remote_status", resp.StatusCode)
+}
+
+// CheckResponse - Check, for the (response, error) of an HTTP client; err is raised
+// as is, an error response as FromResponse maps it, with its body closed. Otherwise
+// the response is returned
+//
+//	resp := eros.CheckResponse(http.Get(url))
+//	defer resp.Body.Close()
+func CheckResponse(resp *http.Response, err error) *http.Response {
+	if err != nil {
+		countCheck(1)
+		raise(CastOrWrap(err))
+	}
+	if rerr := FromResponse(resp); rerr != nil {
+		if resp.Body != nil {
+			resp.Body.Close()
+		}
+		countCheck(1)
+		raise(rerr)
+	}
+	return resp
+}
+
+// mapRemote - the chain the first registered mapper to know the response maps it to
+func mapRemote(status int, body []byte) *Error {
+	remoteMu.RLock()
+	defer remoteMu.RUnlock()
+	for i := len(remoteMappers) - 1; i >= 0; i-- {
+		if err := remoteMappers[i](status, body); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// remoteDefault - the chain a response no mapper knew maps to
+func remoteDefault(status int, body []byte) *Error {
+	var p Problem
+	if json.Unmarshal(body, &p) == nil && (p.Detail != "" || p.Title != "") {
+		msg := p.Detail
+		if msg == "" {
+			msg = p.Title
+		}
+		code := p.Code
+		if code == "" {
+			code = codeForStatus(status)
+		}
+		return newError(msg, 2).WithCode(code)
+	}
+	return newError("remote responded "+http.StatusText(status), 2).WithCode(codeForStatus(status))
+}
+
+// codeForStatus - the code of the taxonomy an HTTP status stands for, if any
+func codeForStatus(status int) string {
+	switch status {
+	case 400:
+		return InvalidArgument
+	case 401:
+		return Unauthenticated
+	case 403:
+		return PermissionDenied
+	case 404:
+		return NotFound
+	case 409:
+		return AlreadyExists
+	case 499:
+		return Canceled
+	case 503:
+		return Unavailable
+	case 504:
+		return DeadlineExceeded
+	}
+	if status >= 500 {
+		return Internal
+	}
+	return ""
+}
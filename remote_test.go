@@ -0,0 +1,64 @@
+package eros
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFromResponse(t *testing.T) {
+	MapRemote(func(status int, body []byte) *Error {
+		var py struct {
+			Detail string `json:"detail"`
+			Kind   string `json:"kind"`
+		}
+		if json.Unmarshal(body, &py) != nil || py.Kind != "quota" {
+			return nil
+		}
+		return New(py.Detail).WithCode(Unavailable)
+	})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/quota":
+			w.WriteHeader(429)
+			w.Write([]byte(`{"detail": "quota exhausted", "kind": "quota"}`))
+		case "/problem":
+			WriteProblem(w, New("no such user").WithCode(NotFound))
+		case "/plain":
+			w.WriteHeader(502)
+		}
+	}))
+	defer srv.Close()
+
+	get := func(path string) *Error {
+		resp, err := http.Get(srv.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		return FromResponse(resp)
+	}
+	if err := get("/ok"); err != nil {
+		t.Errorf("FromResponse() = %v, want nil for a response that didn't fail", err)
+	}
+	if err := get("/quota"); message(err) != "quota exhausted" || Code(err) != Unavailable {
+		t.Errorf("FromResponse() = %v, want the registered mapper's chain", err)
+	}
+	if err := get("/problem"); Code(err) != NotFound || err.Fields()["remote_status"] != 404 {
+		t.Errorf("FromResponse() = %v, want the problem details' code", err)
+	}
+	if err := get("/plain"); Code(err) != Internal {
+		t.Errorf("FromResponse() = %v, want the status coded by the taxonomy", err)
+	}
+
+	var got *Error
+	func() {
+		defer ErrorHandler(func(err *Error) { got = err })()
+		CheckResponse(http.Get(srv.URL + "/plain"))
+	}()
+	if Code(got) != Internal {
+		t.Errorf("CheckResponse() raised %v, want the error response", got)
+	}
+}
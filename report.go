@@ -0,0 +1,110 @@
+package eros
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// ReportHandled - when on, every ErrorHandler collects the errors Handle and
+// HandleMap dealt with on its goroutine while it was in effect, and hands them to its
+// handler in one Warning once the boundary returns, so a request's non fatal issues
+// can be logged together. Nested boundaries each report their own. Off by default, it
+// costs a goroutine id lookup per boundary and per handled error
+func ReportHandled(on bool) Setting {
+	return func(c *config) {
+		c.reportHandled = on
+	}
+}
+
+// report - the errors handled within one boundary, stacked over the boundary
+// enclosing it on the same goroutine
+type report struct {
+	goid    uint64
+	prev    *report
+	handled []error
+	// abandoned - set once the boundary was collected without closing the report
+	abandoned int32
+}
+
+// boundary - an open report, as the Deferred of its boundary holds it. When the
+// Deferred is never invoked (see Misused) it's collected with the report still open;
+// the report is abandoned then, and dropped the next time its goroutine touches its
+// reports, rather than catching what later boundaries handle
+type boundary struct {
+	r *report
+}
+
+// reports - the innermost open report of each goroutine, by goroutine id. A report is
+// only ever touched by its own goroutine, bar being abandoned
+var reports sync.Map
+
+// openReport - opens a report for a boundary on this goroutine, nil unless
+// ReportHandled is on
+func openReport() *boundary {
+	if !conf().reportHandled {
+		return nil
+	}
+	r := &report{goid: goid()}
+	r.prev = innermost(r.goid)
+	reports.Store(r.goid, r)
+	b := &boundary{r}
+	runtime.SetFinalizer(b, func(b *boundary) {
+		atomic.StoreInt32(&b.r.abandoned, 1)
+	})
+	return b
+}
+
+// innermost - the innermost open report of the goroutine id, nil if none. Abandoned
+// reports on top of it are dropped
+func innermost(id uint64) *report {
+	v, ok := reports.Load(id)
+	if !ok {
+		return nil
+	}
+	top := v.(*report)
+	r := top
+	for r != nil && atomic.LoadInt32(&r.abandoned) == 1 {
+		r = r.prev
+	}
+	if r != top {
+		if r != nil {
+			reports.Store(id, r)
+		} else {
+			reports.Delete(id)
+		}
+	}
+	return r
+}
+
+// noteHandled - adds err to this goroutine's innermost open report, if any
+func noteHandled(err *Error) {
+	if !conf().reportHandled {
+		return
+	}
+	if r := innermost(goid()); r != nil {
+		r.handled = append(r.handled, err)
+	}
+}
+
+// close - closes the report and hands whatever was handled to handler. This is nil
+// safe
+func (b *boundary) close(handler Handler) {
+	if b == nil {
+		return
+	}
+	r := b.r
+	if r.prev != nil {
+		reports.Store(r.goid, r.prev)
+	} else {
+		reports.Delete(r.goid)
+	}
+	if len(r.handled) == 0 {
+		return
+	}
+	res := WrapAll(fmt.Sprintf("%d errors were handled", len(r.handled)), r.handled...).
+		WithSeverity(Warning).
+		WithField("handled", len(r.handled))
+	handler(res)
+}
@@ -0,0 +1,83 @@
+package eros
+
+import (
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestReportHandled(t *testing.T) {
+	Configure(ReportHandled(true))
+	defer Configure(ReportHandled(false))
+
+	errCache := errors.New("cache miss")
+	errQuota := errors.New("over quota")
+	var got []*Error
+	func() {
+		defer ErrorHandler(func(err *Error) {
+			got = append(got, err)
+		})()
+		Cast(0, errCache).Handle(func(*Error) {})
+		func() {
+			defer ErrorHandler(func(err *Error) {
+				if !Is(err, errQuota) || Is(err, errCache) {
+					t.Errorf("expected the inner boundary to report only its own, got %v", err)
+				}
+			})()
+			Cast(0, errQuota).HandleMap(func(*Error) *Error { return nil })
+		}()
+		Cast(0, nil).Handle(func(*Error) {})
+	}()
+	if len(got) != 1 {
+		t.Fatalf("expected one report, got %v", got)
+	}
+	if got[0].Severity() != Warning || got[0].Fields()["handled"] != 1 || !Is(got[0], errCache) {
+		t.Errorf("expected a warning reporting the handled error, got %v", got[0])
+	}
+
+	got = nil
+	func() {
+		defer ErrorHandler(func(err *Error) {
+			got = append(got, err)
+		})()
+	}()
+	if len(got) != 0 {
+		t.Errorf("expected no report when nothing was handled, got %v", got)
+	}
+}
+
+func TestReportHandledMisused(t *testing.T) {
+	Configure(ReportHandled(true))
+	defer Configure(ReportHandled(false))
+
+	func() {
+		// the footgun; the report is opened, the func closing it never invoked
+		defer ErrorHandler(func(*Error) {})
+	}()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		runtime.GC()
+		if v, ok := reports.Load(goid()); !ok || atomic.LoadInt32(&v.(*report).abandoned) == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("the report of the misused ErrorHandler was never abandoned")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	var got *Error
+	func() {
+		defer ErrorHandler(func(err *Error) { got = err })()
+		Cast(0, errStepFailed).Handle(func(*Error) {})
+	}()
+	if got == nil || got.Fields()["handled"] != 1 {
+		t.Errorf("expected the handled error reported to the boundary, got %v", got)
+	}
+	if _, ok := reports.Load(goid()); ok {
+		t.Errorf("expected no report left open on the goroutine")
+	}
+}
@@ -0,0 +1,145 @@
+package eros
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// Incident - an error as a Reporter is handed it; the shape error trackers such as
+// Sentry or Rollbar take, so adapting a client to Reporter is a matter of copying
+// fields across
+type Incident struct {
+	// Event - what happened to the error, Raised, Recovered or Reported
+	Event Event
+	// Err - the error itself
+	Err *Error
+	// Message - the outermost message
+	Message string
+	// Fingerprint - groups occurrences of the same failure, see Fingerprint
+	Fingerprint string
+	// Level - the error's severity
+	Level Severity
+	// Tags - short, indexed values; the code, fault and event, see Tags
+	Tags map[string]string
+	// Extra - the fields of the whole chain, see AllFields
+	Extra map[string]interface{}
+	// Stack - the chain's trace, see Trace; nil if nothing captured a stack, see
+	// CaptureStacks and WithStack
+	Stack Frames
+}
+
+// Reporter - ships errors to an error tracker, see RegisterReporter. A Check which
+// fails and is recovered comes twice, Raised then Recovered, with the same error ID,
+// so a failure that takes the process down is still reported; trackers that count
+// occurrences will want to skip one of them
+type Reporter interface {
+	Report(inc Incident)
+}
+
+var (
+	reportersMu sync.Mutex
+	reporters   = map[Reporter]func(){}
+)
+
+// RegisterReporter - has r report every error a Check raises, an ErrorHandler
+// recovers or which is handed to Report. r is told apart by identity, so must be
+// comparable, e.g. a pointer. Registering r twice is a no op
+func RegisterReporter(r Reporter) {
+	reportersMu.Lock()
+	defer reportersMu.Unlock()
+	if _, ok := reporters[r]; ok {
+		return
+	}
+	reporters[r] = Observe(func(ev Event, err *Error) {
+		switch ev {
+		case Raised, Recovered, Reported:
+			r.Report(incident(ev, err))
+		}
+	})
+}
+
+// DeregisterReporter - stops r reporting, as registered with RegisterReporter
+func DeregisterReporter(r Reporter) {
+	reportersMu.Lock()
+	defer reportersMu.Unlock()
+	if remove, ok := reporters[r]; ok {
+		remove()
+		delete(reporters, r)
+	}
+}
+
+// Report - hands err to the registered reporters, and the observers, explicitly; for
+// errors that are handled without ever being raised. Reporting an error handles it.
+// This is nil safe
+func Report(err error) {
+	if err == nil {
+		return
+	}
+	e := CastOrWrap(err)
+	mark(e)
+	notify(Reported, e)
+}
+
+// incident - err as reporters are handed it
+func incident(ev Event, err *Error) Incident {
+	inc := Incident{
+		Event:       ev,
+		Err:         err,
+		Message:     message(err),
+		Fingerprint: Fingerprint(err),
+		Level:       err.Severity(),
+		Tags:        Tags(ev, err),
+		Extra:       allFields(err),
+	}
+	inc.Stack = Trace(err)
+	return inc
+}
+
+// jsonReporter - writes every incident to w as a line of JSON
+type jsonReporter struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// JSONReporter - a Reporter writing every incident to w as a line of JSON, e.g. to
+// os.Stdout for a log shipper to pick up
+func JSONReporter(w io.Writer) Reporter {
+	return &jsonReporter{enc: json.NewEncoder(w)}
+}
+
+// jsonFrame - a frame as JSONReporter writes it
+type jsonFrame struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+// Report - implement Reporter
+func (r *jsonReporter) Report(inc Incident) {
+	line := struct {
+		Time        time.Time              `json:"time"`
+		ID          string                 `json:"id,omitempty"`
+		Message     string                 `json:"message"`
+		Level       string                 `json:"level"`
+		Fingerprint string                 `json:"fingerprint"`
+		Tags        map[string]string      `json:"tags"`
+		Extra       map[string]interface{} `json:"extra,omitempty"`
+		Stack       []jsonFrame            `json:"stack,omitempty"`
+	}{
+		Time:        time.Now(),
+		ID:          inc.Err.ID(),
+		Message:     inc.Message,
+		Level:       inc.Level.String(),
+		Fingerprint: inc.Fingerprint,
+		Tags:        inc.Tags,
+		Extra:       inc.Extra,
+	}
+	for _, f := range inc.Stack {
+		line.Stack = append(line.Stack, jsonFrame{f.Function, f.File, f.Line})
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_ = r.enc.Encode(line)
+}
@@ -0,0 +1,69 @@
+package eros
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+// collector - a Reporter keeping what it's handed
+type collector struct {
+	incidents []Incident
+}
+
+func (c *collector) Report(inc Incident) {
+	c.incidents = append(c.incidents, inc)
+}
+
+func TestRegisterReporter(t *testing.T) {
+	c := &collector{}
+	RegisterReporter(c)
+	RegisterReporter(c)
+
+	func() {
+		defer ErrorHandler(func(*Error) {})()
+		Check(WithStack(New("db down").WithCode(Unavailable).WithField("table", "users")))
+	}()
+	Report(New("cache miss storm"))
+	Report(nil)
+	CheckWarn(New("not reported"))
+
+	DeregisterReporter(c)
+	Report(New("after deregistering"))
+
+	if len(c.incidents) != 3 {
+		t.Fatalf("expected raised, recovered and reported once each, got %d", len(c.incidents))
+	}
+	raised, recovered, reported := c.incidents[0], c.incidents[1], c.incidents[2]
+	if raised.Event != Raised || recovered.Event != Recovered || reported.Event != Reported {
+		t.Errorf("expected Raised, Recovered and Reported in turn, got %v, %v and %v",
+			raised.Event, recovered.Event, reported.Event)
+	}
+	if raised.Err.ID() != recovered.Err.ID() {
+		t.Errorf("expected the raised and recovered error to share an ID")
+	}
+	if recovered.Tags["code"] != Unavailable || recovered.Tags["fault"] != "server" ||
+		recovered.Extra["table"] != "users" || len(recovered.Stack) == 0 {
+		t.Errorf("incident = %+v, want the code, fault, fields and stack", recovered)
+	}
+	if reported.Message != "cache miss storm" || reported.Stack != nil {
+		t.Errorf("incident = %+v, want the reported error without a stack", reported)
+	}
+}
+
+func TestJSONReporter(t *testing.T) {
+	var buf bytes.Buffer
+	r := JSONReporter(&buf)
+	RegisterReporter(r)
+	Report(New("quota exceeded").WithCode(PermissionDenied))
+	DeregisterReporter(r)
+
+	var line map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatalf("expected a line of JSON, got %q: %v", buf.String(), err)
+	}
+	tags, _ := line["tags"].(map[string]interface{})
+	if line["message"] != "quota exceeded" || line["level"] != "warning" || tags["code"] != PermissionDenied {
+		t.Errorf("JSONReporter() wrote %s", buf.String())
+	}
+}
@@ -1,6 +1,10 @@
 package eros
 
-import "reflect"
+import (
+	"context"
+	"reflect"
+	"time"
+)
 
 // Result - represents the traditional (value, error) tuple as an actual return
 // type.
@@ -12,10 +16,15 @@ type Result[T any] struct {
 // Handler - type'ifies our error handler function
 type Handler func(res *Error)
 
+// HandlerCtx - a Handler which is also given the context of the code it recovered,
+// see ErrorHandlerCtx
+type HandlerCtx func(ctx context.Context, err *Error)
+
 // Check - raises a panic if err != nil
 func (r Result[T]) Check(mesgs ...string) T {
 	if r.Error != nil {
-		panic(CastOrWrap(r.Error, mesgs...))
+		countCheck(1)
+		raise(CastOrWrap(r.Error, mesgs...))
 	}
 	return r.Value
 }
@@ -24,37 +33,126 @@ func (r Result[T]) Check(mesgs ...string) T {
 // the user the opportunity to decide whether or not fail through instead of fast
 func (r Result[T]) Handle(handler Handler) T {
 	if r.Error != nil {
-		handler(CastOrWrap(r.Error))
+		err := CastOrWrap(r.Error)
+		mark(err)
+		handler(err)
+		noteHandled(err)
 	}
 	return r.Value
 }
 
+// MapHandler - a handler which may translate the error it's given, see HandleMap
+type MapHandler func(err *Error) *Error
+
+// HandleMap - Handle, where the handler may return a replacement for the error which
+// is stored in the returned Result, so whatever checks it next sees the translated
+// error. A nil replacement keeps the original error
+func (r Result[T]) HandleMap(handler MapHandler) Result[T] {
+	if r.Error != nil {
+		err := CastOrWrap(r.Error)
+		mark(err)
+		if replacement := handler(err); replacement != nil {
+			r.Error = replacement
+		}
+		noteHandled(err)
+	}
+	return r
+}
+
 // Check - is used to apply a default handler (or a full on panic) to an existing
 // function that only returns an error.
 func Check(err error, mesgs ...string) {
 	if err != nil {
-		panic(CastOrWrap(err, mesgs...))
+		countCheck(1)
+		raise(CastOrWrap(err, mesgs...))
 	}
 	return
 }
 
+// CheckReturn - Check, for code that can't rely on a recover boundary (e.g. inside a
+// library); the error is normalized exactly as Check would raise it, but returned.
+// nil if err is nil
+func CheckReturn(err error, mesgs ...string) *Error {
+	if err == nil {
+		return nil
+	}
+	return CastOrWrap(err, mesgs...)
+}
+
+// Get - the value and the error normalized as Check would raise it, without panicking
+func (r Result[T]) Get() (T, *Error) {
+	return r.Value, CheckReturn(r.Error)
+}
+
 // CheckNotNil - Prove val isn't nil and return val, otherwise invoke the error handler
 func CheckNotNil[T any](val T, msg string) T {
 	v := reflect.ValueOf(val)
 	if v.IsNil() {
-		panic(New(msg))
+		raise(New(msg))
 	}
 	return val
 }
 
+// CastTo - asserts v is a T and returns it, otherwise invokes the error handler with
+// the expected and actual types. This is v.(T) for code using the Check flow
+func CastTo[T any](v interface{}) T {
+	t, ok := v.(T)
+	if !ok {
+		raise(Newf("failed to cast %T to %s", v, reflect.TypeOf((*T)(nil)).Elem()))
+	}
+	return t
+}
+
 // CheckVal (checks) without casting and returns the value portion of the value/error
 // tuple
 func CheckVal[T any](val T, err error) T {
-	result := Result[T]{
-		Value: val,
-		Error: err,
+	if err != nil {
+		countCheck(1)
+		raise(CastOrWrap(err))
 	}
-	return result.Check()
+	return val
+}
+
+// CheckVal2 - CheckVal, for APIs returning two values and an error
+func CheckVal2[A, B any](a A, b B, err error) (A, B) {
+	if err != nil {
+		countCheck(1)
+		raise(CastOrWrap(err))
+	}
+	return a, b
+}
+
+// CheckVal3 - CheckVal, for APIs returning three values and an error
+func CheckVal3[A, B, C any](a A, b B, c C, err error) (A, B, C) {
+	if err != nil {
+		countCheck(1)
+		raise(CastOrWrap(err))
+	}
+	return a, b, c
+}
+
+// CheckDeadline - CheckVal, for an operation with a latency budget; even if err is
+// nil, taking longer than budget since started fails it, a timeout coded
+// DeadlineExceeded with the elapsed time and the budget as fields. The value arrived
+// too late to be of use
+//
+//	start := time.Now()
+//	user, err := db.Load(id)
+//	user = eros.CheckDeadline(user, err, start, 50*time.Millisecond)
+func CheckDeadline[T any](val T, err error, started time.Time, budget time.Duration) T {
+	if err != nil {
+		countCheck(1)
+		raise(CastOrWrap(err))
+	}
+	if elapsed := time.Since(started); elapsed > budget {
+		countCheck(1)
+		raise(Newf("took %s, over the budget of %s", elapsed, budget).
+			WithCode(DeadlineExceeded).
+			WithTimeout(true).
+			WithField("elapsed", elapsed).
+			WithField("budget", budget))
+	}
+	return val
 }
 
 // Cast - Cast the return contents to a result type, which can either check or handle
@@ -67,20 +165,255 @@ func Cast[T any](val T, err error) (res *Result[T]) {
 	return &result
 }
 
+// Result2 - Result, for APIs returning two values and an error
+type Result2[A, B any] struct {
+	First  A
+	Second B
+	Error  error
+}
+
+// Cast2 - Cast, for APIs returning two values and an error
+func Cast2[A, B any](a A, b B, err error) *Result2[A, B] {
+	return &Result2[A, B]{
+		First:  a,
+		Second: b,
+		Error:  err,
+	}
+}
+
+// Check - raises a panic if err != nil, see Result.Check
+func (r Result2[A, B]) Check(mesgs ...string) (A, B) {
+	if r.Error != nil {
+		countCheck(1)
+		raise(CastOrWrap(r.Error, mesgs...))
+	}
+	return r.First, r.Second
+}
+
+// Handle - handles the error in a lambda, then still returns the values, see
+// Result.Handle
+func (r Result2[A, B]) Handle(handler Handler) (A, B) {
+	if r.Error != nil {
+		err := CastOrWrap(r.Error)
+		mark(err)
+		handler(err)
+		noteHandled(err)
+	}
+	return r.First, r.Second
+}
+
+// Get - the values and the error normalized as Check would raise it, without panicking
+func (r Result2[A, B]) Get() (A, B, *Error) {
+	return r.First, r.Second, CheckReturn(r.Error)
+}
+
+// Result3 - Result, for APIs returning three values and an error
+type Result3[A, B, C any] struct {
+	First  A
+	Second B
+	Third  C
+	Error  error
+}
+
+// Cast3 - Cast, for APIs returning three values and an error
+func Cast3[A, B, C any](a A, b B, c C, err error) *Result3[A, B, C] {
+	return &Result3[A, B, C]{
+		First:  a,
+		Second: b,
+		Third:  c,
+		Error:  err,
+	}
+}
+
+// Check - raises a panic if err != nil, see Result.Check
+func (r Result3[A, B, C]) Check(mesgs ...string) (A, B, C) {
+	if r.Error != nil {
+		countCheck(1)
+		raise(CastOrWrap(r.Error, mesgs...))
+	}
+	return r.First, r.Second, r.Third
+}
+
+// Handle - handles the error in a lambda, then still returns the values, see
+// Result.Handle
+func (r Result3[A, B, C]) Handle(handler Handler) (A, B, C) {
+	if r.Error != nil {
+		err := CastOrWrap(r.Error)
+		mark(err)
+		handler(err)
+		noteHandled(err)
+	}
+	return r.First, r.Second, r.Third
+}
+
+// Get - the values and the error normalized as Check would raise it, without panicking
+func (r Result3[A, B, C]) Get() (A, B, C, *Error) {
+	return r.First, r.Second, r.Third, CheckReturn(r.Error)
+}
+
 // ErrorHandler - handle but only get err instead of the full result. This lack
 // of information may for the most part beO OK especially in legacy situations.
-// Note; this will work even if the panic' error is wrapped / nested deep
-func ErrorHandler(handler Handler) func() {
+// Note; this will work even if the panic' error is wrapped / nested deep. With
+// ReportHandled on, handler is also given the errors handled within, see ReportHandled
+func ErrorHandler(handler Handler) Deferred {
+	rep, inv := openReport(), watch()
+	// recover() only works called directly by the deferred func, so this is it
 	return func() {
+		inv.done()
+		defer rep.close(handler)
 		// if we're in a panic, then
-		if r := recover(); r != nil {
-			// check to see if we're an eros.Error
-			if e, ok := r.(error); ok {
-				handler(CastOrWrap(e))
-				return
-			}
-			// we can keep panicking, this isn't coming from us
-			panic(r)
+		if r := raised(recover()); r != nil {
+			handleRecovered(r, handler)
+		}
+	}
+}
+
+// Recover - ErrorHandler, deferred as is rather than invoked to get the func to defer,
+// so there's no () to forget;
+//
+//	defer eros.Recover(handler)
+func Recover(handler Handler) {
+	if r := raised(recover()); r != nil {
+		handleRecovered(r, handler)
+	}
+}
+
+// Catch - recovers what a Check raises into the caller's named error return, so a
+// function using Check can still present an idiomatic (T, error) signature. It must
+// be deferred directly;
+//
+//	func load() (cfg Config, err error) {
+//		defer eros.Catch(&err)
+func Catch(err *error) {
+	if r := raised(recover()); r != nil {
+		handleRecovered(r, func(e *Error) {
+			*err = e
+		})
+	}
+}
+
+// CatchInto - Catch, for functions returning an *Error
+func CatchInto(err **Error) {
+	if r := raised(recover()); r != nil {
+		handleRecovered(r, func(e *Error) {
+			*err = e
+		})
+	}
+}
+
+// ErrorHandlerCtx - ErrorHandler, handing ctx to handler along with the error so it
+// can do ctx scoped work (end a span, notify within the deadline) without reaching
+// for global state
+//
+//	defer ErrorHandlerCtx(ctx, handler)()
+func ErrorHandlerCtx(ctx context.Context, handler HandlerCtx) Deferred {
+	h := func(err *Error) {
+		handler(ctx, err)
+	}
+	rep, inv := openReport(), watch()
+	return func() {
+		inv.done()
+		defer rep.close(h)
+		if r := raised(recover()); r != nil {
+			handleRecovered(r, h)
 		}
 	}
 }
+
+// ErrorHandled - ErrorHandler, which also reports whether it recovered an error. The
+// bool is only meaningful once deferFn has run, so read it from a func deferred
+// before deferFn;
+//
+//	deferFn, recovered := ErrorHandled(handler)
+//	defer func() {
+//		if *recovered { ... }
+//	}()
+//	defer deferFn()
+func ErrorHandled(handler Handler) (deferFn Deferred, recovered *bool) {
+	recovered = new(bool)
+	rep, inv := openReport(), watch()
+	return func() {
+		inv.done()
+		defer rep.close(handler)
+		if r := raised(recover()); r != nil {
+			handleRecovered(r, handler)
+			*recovered = true
+		}
+	}, recovered
+}
+
+// raise - tells the observers err was raised, then panics with it for the nearest
+// ErrorHandler to recover; in panic free mode it's left in the goroutine's slot instead
+func raise(err *Error) {
+	countError(err)
+	notify(Raised, err)
+	if conf().panicFree {
+		store(err)
+		return
+	}
+	panic(checkPanic{err})
+}
+
+// checkPanic - what raise panics with, so a panic raised by a Check is told apart
+// from any other error something panicked with without guessing, see StrictRecover.
+// It's an error itself, wrapping the one raised, for recovers which aren't ours
+type checkPanic struct {
+	err *Error
+}
+
+// Error - implement the error interface
+func (p checkPanic) Error() string {
+	return p.err.Error()
+}
+
+// Unwrap - implement the Unwrap interface
+func (p checkPanic) Unwrap() error {
+	return p.err
+}
+
+// StrictRecover - when on, ErrorHandler and friends only recover what a Check (or one
+// of its variants) raised; anything else carries on panicking, errors included, as
+// it's a bug rather than a failure that was planned for. Protect, which is there to
+// contain any panic, still does, and HardenedHandler still recovers any panic with an
+// error, runtime errors included. Off by default
+func StrictRecover(on bool) Setting {
+	return func(c *config) {
+		c.strict = on
+	}
+}
+
+// unchecked - the error a Check raised if that's what r is, otherwise r itself
+func unchecked(r interface{}) interface{} {
+	if p, ok := r.(checkPanic); ok {
+		return p.err
+	}
+	return r
+}
+
+// handleRecovered - hands a recovered panic to handler. recover() only works when
+// called directly by the deferred func, so this is everything after it
+func handleRecovered(r interface{}, handler Handler) {
+	if p, ok := r.(checkPanic); ok {
+		handleError(p.err, handler)
+		return
+	}
+	// check to see if we're an eros.Error
+	if e, ok := r.(error); ok && !conf().strict {
+		handleError(CastOrWrap(e), handler)
+		return
+	}
+	// we can keep panicking, this isn't coming from us
+	panic(r)
+}
+
+// handleError - hands err, recovered from a panic, to handler
+func handleError(err *Error, handler Handler) {
+	// a fatal error may well be about other goroutines, e.g. a deadlock
+	if conf().goroutines && err.Severity() == Fatal {
+		err.goroutines = dumpGoroutines()
+	}
+	countError(err)
+	notify(Recovered, err)
+	mark(err)
+	handler(err)
+}
@@ -1,9 +1,12 @@
 package eros
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
+	"testing"
+	"time"
 )
 
 // ExampleCheck - test fail fast instead of fail through
@@ -70,10 +73,10 @@ func ExampleCheckNotNil() {
 
 }
 
-// ExampleCheckAndSet -Test both the global handler (in ReadFileBuffer) and a
+// Example_checkAndSet - Test both the global handler (in ReadFileBuffer) and a
 // local handler. A local handler isn't run on the defer (or on the way out)
 // which is useful if you want to fail through and keep going
-func ExampleCheckAndSet() {
+func Example_checkAndSet() {
 
 	var e *Error
 
@@ -132,3 +135,232 @@ func ReadFileBuffer(filepath string) (res Result[string]) {
 	}
 	return
 }
+
+// ExampleErrorHandled - conditional cleanup depending on whether we recovered
+func ExampleErrorHandled() {
+
+	deferFn, recovered := ErrorHandled(func(err *Error) {
+		fmt.Println(err.Unwrap())
+	})
+	// deferred first so that it runs after deferFn
+	defer func() {
+		if *recovered {
+			fmt.Println("rolling back")
+		}
+	}()
+	defer deferFn()
+
+	Check(os.Remove("/opt/abc/baddir/file"))
+
+	// Output: remove /opt/abc/baddir/file: no such file or directory
+	// rolling back
+}
+
+// ExampleErrorHandlerCtx - the handler gets the context of the request it recovered
+func ExampleErrorHandlerCtx() {
+
+	type requestID struct{}
+	ctx := context.WithValue(context.Background(), requestID{}, "req-42")
+
+	defer ErrorHandlerCtx(ctx, func(ctx context.Context, err *Error) {
+		fmt.Println(ctx.Value(requestID{}), err.Unwrap())
+	})()
+
+	Check(os.Remove("/opt/abc/baddir/file"))
+
+	// Output: req-42 remove /opt/abc/baddir/file: no such file or directory
+}
+
+// loadConfig - uses Check, yet returns its error like any other Go function
+func loadConfig(path string) (cfg []byte, err error) {
+	defer Catch(&err)
+	cfg = CheckVal(os.ReadFile(path))
+	return cfg, nil
+}
+
+// ExampleCatch - the failed Check comes back as the error return
+func ExampleCatch() {
+
+	_, err := loadConfig("/opt/abc/baddir/config.yaml")
+	fmt.Println(err.(*Error).Unwrap())
+
+	// Output: open /opt/abc/baddir/config.yaml: no such file or directory
+}
+
+// ExampleCatchInto - the same, for a function returning an *Error
+func ExampleCatchInto() {
+
+	remove := func(path string) (err *Error) {
+		defer CatchInto(&err)
+		Check(os.Remove(path))
+		return nil
+	}
+	fmt.Println(remove("/opt/abc/baddir/file").Unwrap())
+
+	// Output: remove /opt/abc/baddir/file: no such file or directory
+}
+
+// ExampleCastTo - a failed type assertion goes to the error handler
+func ExampleCastTo() {
+
+	defer ErrorHandler(func(err *Error) {
+		fmt.Println(err.msg)
+	})()
+
+	var v interface{} = "eros"
+	s := CastTo[string](v)
+	fmt.Println(s)
+
+	CastTo[fmt.Stringer](v)
+
+	// Output: eros
+	// failed to cast string to fmt.Stringer
+}
+
+// ExampleResult_HandleMap - translate a low level error before checking it
+func ExampleResult_HandleMap() {
+
+	defer ErrorHandler(func(err *Error) {
+		fmt.Println(err.msg, Code(err))
+	})()
+
+	Cast(os.Open("/opt/abc/baddir/file")).HandleMap(func(err *Error) *Error {
+		if Is(err, os.ErrNotExist) {
+			return Wrap(err, "no such config").WithCode("CONFIG_MISSING")
+		}
+		return nil
+	}).Check()
+
+	// Output: no such config CONFIG_MISSING
+}
+
+// ExampleResult_Get - the value and error without a recover boundary
+func ExampleResult_Get() {
+
+	if _, err := Cast(os.Open("/opt/abc/baddir/file")).Get(); err != nil {
+		fmt.Println(err.Unwrap())
+	}
+	fmt.Println(CheckReturn(nil) == nil)
+
+	// Output: open /opt/abc/baddir/file: no such file or directory
+	// true
+}
+
+func TestCheckDeadline(t *testing.T) {
+	var got *Error
+	load := func(took time.Duration, err error) (v int) {
+		defer ErrorHandler(func(err *Error) { got = err })()
+		got = nil
+		return CheckDeadline(7, err, time.Now().Add(-took), 50*time.Millisecond)
+	}
+
+	if v := load(0, nil); v != 7 || got != nil {
+		t.Errorf("CheckDeadline() = %v, %v, want the value in time", v, got)
+	}
+	if load(0, errStepFailed); !Is(got, errStepFailed) {
+		t.Errorf("CheckDeadline() raised %v, want the error itself", got)
+	}
+	load(time.Second, nil)
+	if Code(got) != DeadlineExceeded || !IsTimeout(got) || got.Fields()["budget"] != 50*time.Millisecond {
+		t.Errorf("CheckDeadline() raised %v, want a late value coded DeadlineExceeded", got)
+	}
+}
+
+func TestCheckVal2(t *testing.T) {
+	split := func(s string) (string, string, error) {
+		if s == "" {
+			return "", "", errStepFailed
+		}
+		return s[:1], s[1:], nil
+	}
+	var got *Error
+	first, rest := func() (a, b string) {
+		defer ErrorHandler(func(err *Error) { got = err })()
+		return CheckVal2(split("eros"))
+	}()
+	if first != "e" || rest != "ros" || got != nil {
+		t.Errorf("CheckVal2() = %q, %q, %v, want both values", first, rest, got)
+	}
+	func() {
+		defer ErrorHandler(func(err *Error) { got = err })()
+		CheckVal2(split(""))
+	}()
+	if !Is(got, errStepFailed) {
+		t.Errorf("CheckVal2() raised %v, want the error", got)
+	}
+
+	three := func(fail bool) (int, string, bool, error) {
+		if fail {
+			return 0, "", false, errStepFailed
+		}
+		return 1, "two", true, nil
+	}
+	got = nil
+	func() {
+		defer ErrorHandler(func(err *Error) { got = err })()
+		if n, s, ok := CheckVal3(three(false)); n != 1 || s != "two" || !ok {
+			t.Errorf("CheckVal3() = %v, %v, %v, want all three values", n, s, ok)
+		}
+		CheckVal3(three(true))
+	}()
+	if !Is(got, errStepFailed) {
+		t.Errorf("CheckVal3() raised %v, want the error", got)
+	}
+}
+
+func TestStrictRecover(t *testing.T) {
+	Configure(StrictRecover(true))
+	defer Configure(StrictRecover(false))
+
+	var got *Error
+	func() {
+		defer ErrorHandler(func(err *Error) { got = err })()
+		Check(errStepFailed)
+	}()
+	if !Is(got, errStepFailed) {
+		t.Errorf("ErrorHandler() handled %v, want what Check raised", got)
+	}
+
+	var repanicked interface{}
+	func() {
+		defer func() { repanicked = recover() }()
+		defer ErrorHandler(func(err *Error) { t.Errorf("ErrorHandler() recovered a foreign panic %v", err) })()
+		panic(errStepFailed)
+	}()
+	if repanicked != errStepFailed {
+		t.Errorf("ErrorHandler() let %v through, want the foreign panic", repanicked)
+	}
+
+	if err := Protect(func() { panic(errStepFailed) }); !Is(err, errStepFailed) {
+		t.Errorf("Protect() = %v, want the foreign panic contained", err)
+	}
+}
+
+func TestCast2(t *testing.T) {
+	split := func(s string) (string, string, error) {
+		if s == "" {
+			return "", "", errStepFailed
+		}
+		return s[:1], s[1:], nil
+	}
+	if first, rest := Cast2(split("eros")).Check(); first != "e" || rest != "ros" {
+		t.Errorf("Cast2().Check() = %q, %q, want both values", first, rest)
+	}
+	var got *Error
+	func() {
+		defer ErrorHandler(func(err *Error) { got = err })()
+		Cast2(split("")).Check("splitting")
+	}()
+	if !Is(got, errStepFailed) {
+		t.Errorf("Cast2().Check() raised %v, want the error", got)
+	}
+
+	got = nil
+	Cast3(1, "two", true, errStepFailed).Handle(func(err *Error) { got = err })
+	if !Is(got, errStepFailed) {
+		t.Errorf("Cast3().Handle() handled %v, want the error", got)
+	}
+	if a, b, c, err := Cast3(1, "two", true, nil).Get(); a != 1 || b != "two" || !c || err != nil {
+		t.Errorf("Cast3().Get() = %v, %v, %v, %v, want the values", a, b, c, err)
+	}
+}
@@ -0,0 +1,90 @@
+package eros
+
+import (
+	"time"
+)
+
+// BackoffFunc - how long to wait before the given attempt, counting from 1. The first
+// attempt is never delayed
+type BackoffFunc func(attempt int) time.Duration
+
+// Retry - calls fn until it succeeds, fails in a way that isn't retryable (see
+// IsRetryable) or has been tried attempts times, waiting backoff between tries (nil
+// doesn't wait). Every failed attempt is wrapped with its attempt number, the delay
+// before it and the time elapsed since the first try, and chained newest first, under
+// a summary of how many attempts were made, how long it all took and how much of that
+// was spent waiting. Returns nil on success
+func Retry(attempts int, backoff BackoffFunc, fn func() error) *Error {
+	if attempts < 1 {
+		attempts = 1
+	}
+	var (
+		res    *Error
+		tried  int
+		waited time.Duration
+		start  = time.Now()
+	)
+	for n := 1; n <= attempts; n++ {
+		var delay time.Duration
+		if n > 1 && backoff != nil {
+			delay = backoff(n)
+			time.Sleep(delay)
+			waited += delay
+		}
+		tried = n
+		err := fn()
+		if err == nil {
+			return nil
+		}
+		attempt := Wrapf(err, "attempt %d failed", n).
+			WithField("attempt", n).
+			WithField("delay", delay).
+			WithField("elapsed", time.Since(start))
+		if res != nil {
+			attempt.WithCause(res)
+		}
+		res = attempt
+		if !IsRetryable(err) {
+			break
+		}
+	}
+	return Wrapf(res, "failed after %d attempts", tried).
+		WithField("attempts", tried).
+		WithField("elapsed", time.Since(start)).
+		WithField("waited", waited)
+}
+
+// RetryVal - Retry, for a fn returning a value; that of the attempt which succeeded
+func RetryVal[T any](attempts int, backoff BackoffFunc, fn func() (T, error)) (res Result[T]) {
+	if err := Retry(attempts, backoff, func() (err error) {
+		res.Value, err = fn()
+		return err
+	}); err != nil {
+		return Result[T]{Error: err}
+	}
+	return res
+}
+
+// WithRetryable - flags this link in the chain as a transient failure, one worth
+// retrying, see Retry. This is nil safe
+func (e *Error) WithRetryable() *Error {
+	if e != nil {
+		e.retryable = true
+	}
+	return e
+}
+
+// IsRetryable - whether err is worth retrying; some link in its chain was flagged
+// with WithRetryable, or has a Retryable() bool method saying so
+func IsRetryable(err error) bool {
+	retryable := false
+	walk(err, func(l error) bool {
+		if e := link(l); e != nil {
+			retryable = e.retryable
+		} else if r, ok := l.(interface{ Retryable() bool }); ok {
+			retryable = r.Retryable()
+		}
+		return !retryable
+	})
+	return retryable
+}
@@ -0,0 +1,132 @@
+package eros
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// transientError - a failure that says it's worth retrying
+type transientError struct {
+	error
+}
+
+func (transientError) Retryable() bool { return true }
+
+var errFlaky = transientError{errors.New("flaky")}
+
+func TestRetry(t *testing.T) {
+	var delays []int
+	backoff := func(attempt int) time.Duration {
+		delays = append(delays, attempt)
+		return time.Millisecond
+	}
+	tests := []struct {
+		name      string
+		attempts  int
+		failures  int
+		wantCalls int
+		wantErr   bool
+	}{
+		{"Test succeeds first time", 3, 0, 1, false},
+		{"Test succeeds on the last attempt", 3, 2, 3, false},
+		{"Test gives up", 3, 5, 3, true},
+		{"Test at least one attempt", 0, 5, 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			delays = nil
+			err := Retry(tt.attempts, backoff, func() error {
+				calls++
+				if calls <= tt.failures {
+					return errFlaky
+				}
+				return nil
+			})
+			if calls != tt.wantCalls {
+				t.Errorf("Retry() called fn %d times, want %d", calls, tt.wantCalls)
+			}
+			if len(delays) != calls-1 {
+				t.Errorf("Retry() backed off %v, want once before every retry", delays)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Retry() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !Is(err, errFlaky) {
+				t.Errorf("Retry() error = %v, want the cause in the chain", err)
+			}
+		})
+	}
+}
+
+func TestRetryMetadata(t *testing.T) {
+	err := Retry(3, func(int) time.Duration { return time.Millisecond }, func() error { return errFlaky })
+	if err == nil {
+		t.Fatal("Retry() expected an error")
+	}
+	summary := err.Fields()
+	if summary["attempts"] != 3 {
+		t.Errorf("summary attempts = %v, want 3", summary["attempts"])
+	}
+	if summary["waited"] != 2*time.Millisecond {
+		t.Errorf("summary waited = %v, want 2ms", summary["waited"])
+	}
+	if summary["elapsed"].(time.Duration) < 2*time.Millisecond {
+		t.Errorf("summary elapsed = %v, want at least the time waited", summary["elapsed"])
+	}
+
+	var got []int
+	var elapsed []time.Duration
+	for e := Unwrap(err); e != nil; e = Unwrap(e) {
+		if l := link(e); l != nil && l.fields["attempt"] != nil {
+			got = append(got, l.fields["attempt"].(int))
+			elapsed = append(elapsed, l.fields["elapsed"].(time.Duration))
+			wantDelay := time.Millisecond
+			if l.fields["attempt"] == 1 {
+				wantDelay = 0
+			}
+			if l.fields["delay"] != wantDelay {
+				t.Errorf("attempt %v delay = %v, want %v", l.fields["attempt"], l.fields["delay"], wantDelay)
+			}
+		}
+	}
+	if len(got) != 3 || got[0] != 3 || got[1] != 2 || got[2] != 1 {
+		t.Errorf("attempts in the chain = %v, want [3 2 1]", got)
+	}
+	if len(elapsed) == 3 && !(elapsed[0] >= elapsed[1] && elapsed[1] >= elapsed[2]) {
+		t.Errorf("elapsed in the chain = %v, want newest first", elapsed)
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	calls := 0
+	err := Retry(3, nil, func() error {
+		calls++
+		return errors.New("bad credentials")
+	})
+	if calls != 1 || err.Fields()["attempts"] != 1 {
+		t.Errorf("Retry() called fn %d times, want a failure that isn't retryable tried once", calls)
+	}
+
+	calls = 0
+	res := RetryVal(3, nil, func() (int, error) {
+		if calls++; calls < 3 {
+			return 0, Wrap(New("connection reset").WithRetryable(), "dialing")
+		}
+		return 42, nil
+	})
+	if res.Error != nil || res.Value != 42 || calls != 3 {
+		t.Errorf("RetryVal() = %v, %v after %d calls, want 42 on the third", res.Value, res.Error, calls)
+	}
+
+	res = RetryVal(2, nil, func() (int, error) { return 7, errFlaky })
+	if !Is(res.Error, errFlaky) || res.Value != 0 {
+		t.Errorf("RetryVal() = %v, %v, want only the error", res.Value, res.Error)
+	}
+
+	if IsRetryable(nil) || IsRetryable(New("permanent")) || !IsRetryable(Wrap(errFlaky, "calling upstream")) {
+		t.Errorf("IsRetryable() didn't go by the chain")
+	}
+}
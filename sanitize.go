@@ -0,0 +1,116 @@
+package eros
+
+import "fmt"
+
+// Profile - what Sanitize keeps of an error for a given audience
+type Profile struct {
+	// Depth - the most links kept, outermost first. 0 keeps them all
+	Depth int
+	// Stacks - whether captured stacks are kept
+	Stacks bool
+	// Fields - whether fields are kept
+	Fields bool
+	// InternalCodes - whether codes which weren't registered Public are kept, those of
+	// the taxonomy (NotFound, Internal, ...) are public
+	InternalCodes bool
+	// PublicMessages - whether messages are replaced by what PublicMessage would say;
+	// messages made without a template may hold anything
+	PublicMessages bool
+}
+
+var (
+	// InternalProfile - for our own logs and tools, everything is kept
+	InternalProfile = Profile{Stacks: true, Fields: true, InternalCodes: true}
+	// PartnerProfile - for trusted integrators; enough of the chain to act on, none of
+	// our internals
+	PartnerProfile = Profile{Depth: 3, Fields: true}
+	// PublicProfile - for anyone; a single link with a public message and code
+	PublicProfile = Profile{Depth: 1, PublicMessages: true}
+)
+
+// Sanitize - a copy of err prepared for the audience of profile. Every link kept keeps
+// its id, time, severity, fault and HTTP status so it can still be correlated with our
+// own logs, and its message as rendered from err. err is left as it was. nil for nil
+func Sanitize(err error, profile Profile) *Error {
+	if err == nil {
+		return nil
+	}
+	var links []error
+	walk(err, func(l error) bool {
+		links = append(links, l)
+		return profile.Depth <= 0 || len(links) < profile.Depth
+	})
+	var res *Error
+	for i := len(links) - 1; i >= 0; i-- {
+		s := sanitizeLink(links[i], profile)
+		if res != nil {
+			s.cause, s.count = res, res.count+1
+		}
+		res = s
+	}
+	if profile.PublicMessages {
+		res.msg, res.rendered = PublicMessage(err), true
+		if res.code = Code(err); !profile.InternalCodes && !isPublicCode(res.code) {
+			res.code = ""
+		}
+	}
+	return res
+}
+
+// sanitizeLink - a copy of the one link l, stripped as profile says
+func sanitizeLink(l error, profile Profile) *Error {
+	s := &Error{msg: message(l)}
+	if code := linkCode(l); profile.InternalCodes || isPublicCode(code) {
+		s.code = code
+	}
+	if e := link(l); e != nil {
+		_, s.rendered = e.render()
+		s.id, s.at, s.severity, s.fault, s.status = e.id, e.at, e.severity, e.fault, e.status
+		s.sentinel = e.sentinel
+		if profile.Stacks {
+			s.stack = e.stack
+		}
+		if profile.Fields {
+			s.fields = e.Fields()
+		}
+	}
+	return s
+}
+
+// isPublicCode - whether code was registered as fine for anyone
+func isPublicCode(code string) bool {
+	info, _ := LookupCode(code)
+	return info.Public
+}
+
+// LimitDepth - a copy of err of at most n links, for transports and UIs with strict
+// size limits. The outermost n-1 are kept as Sanitize keeps them for InternalProfile,
+// the rest replaced by a single link summarizing them, which keeps the root visible;
+// "…and 14 more causes, root: connection refused", with the root's code and fields.
+// n less than 1 is taken as 1. err is left as it was. nil for nil
+func LimitDepth(err error, n int) *Error {
+	if err == nil {
+		return nil
+	}
+	if n < 1 {
+		n = 1
+	}
+	var links []error
+	walk(err, func(l error) bool {
+		links = append(links, l)
+		return true
+	})
+	if len(links) <= n {
+		return Sanitize(err, InternalProfile)
+	}
+	last := len(links) - 1
+	res := sanitizeLink(links[last], InternalProfile)
+	res.msg = fmt.Sprintf("…and %d more causes, root: %s", last-(n-1), res.msg)
+	res.rendered = true
+	for i := n - 2; i >= 0; i-- {
+		s := sanitizeLink(links[i], InternalProfile)
+		s.cause, s.count = res, res.count+1
+		res = s
+	}
+	return res
+}
@@ -0,0 +1,103 @@
+package eros
+
+import (
+	"io"
+	"testing"
+)
+
+func TestSanitize(t *testing.T) {
+	Configure(CaptureStacks(true))
+	defer Configure(CaptureStacks(false))
+	if err := RegisterTemplate(NotFound, "no such {{.Fields.kind}}"); err != nil {
+		t.Fatal(err)
+	}
+	defer RegisterTemplate(NotFound, "")
+
+	root := Wrap(io.EOF, "reading row").WithCode("TEST_DB_READ").WithField("table", "users")
+	notFound := Wrap(root, "looking up").WithCode(NotFound).WithField("kind", "user")
+	err := Wrap(notFound, "handling request").WithField("token", "secret")
+
+	tests := []struct {
+		name       string
+		profile    Profile
+		wantLen    int
+		wantMsg    string
+		wantCode   string
+		wantStack  bool
+		wantFields bool
+	}{
+		{"Test internal keeps everything", InternalProfile, 4, "handling request", NotFound, true, true},
+		{"Test partner drops stacks, internal codes and depth", PartnerProfile, 3, "handling request", NotFound, false, true},
+		{"Test public keeps the public message and code", PublicProfile, 1, "no such user", NotFound, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Sanitize(err, tt.profile)
+			var links []*Error
+			walk(got, func(l error) bool {
+				links = append(links, link(l))
+				return true
+			})
+			if len(links) != tt.wantLen {
+				t.Fatalf("Sanitize() kept %d links, want %d", len(links), tt.wantLen)
+			}
+			if msg, _ := got.render(); msg != tt.wantMsg {
+				t.Errorf("Sanitize() message = %q, want %q", msg, tt.wantMsg)
+			}
+			if got.Code() != tt.wantCode {
+				t.Errorf("Sanitize() code = %q, want %q", got.Code(), tt.wantCode)
+			}
+			if got.ID() != err.ID() {
+				t.Errorf("Sanitize() id = %q, want the original %q", got.ID(), err.ID())
+			}
+			stack, fields := false, false
+			for _, l := range links {
+				stack = stack || len(l.stack) > 0
+				fields = fields || len(l.fields) > 0
+				if l.code == "TEST_DB_READ" && !tt.profile.InternalCodes {
+					t.Errorf("Sanitize() kept the internal code")
+				}
+			}
+			if stack != tt.wantStack || fields != tt.wantFields {
+				t.Errorf("Sanitize() stacks = %v fields = %v, want %v %v", stack, fields, tt.wantStack, tt.wantFields)
+			}
+			if tt.wantLen > 1 && links[1].msg != "no such user" {
+				t.Errorf("Sanitize() templated message = %q, want it rendered from the original", links[1].msg)
+			}
+		})
+	}
+	if err.Fields()["token"] != "secret" || root.code != "TEST_DB_READ" || root.stack == nil {
+		t.Errorf("Sanitize() changed the original")
+	}
+	if Sanitize(nil, PublicProfile) != nil {
+		t.Errorf("Sanitize(nil) should be nil")
+	}
+}
+
+func TestLimitDepth(t *testing.T) {
+	var err error = New("connection refused").WithCode(Unavailable)
+	for i := 0; i < 16; i++ {
+		err = Wrapf(err, "layer %d", i)
+	}
+	limited := LimitDepth(err, 3)
+	var msgs []string
+	walk(limited, func(l error) bool {
+		msgs = append(msgs, message(l))
+		return true
+	})
+	want := []string{"layer 15", "layer 14", "…and 14 more causes, root: connection refused"}
+	if len(msgs) != len(want) {
+		t.Fatalf("LimitDepth() kept %q, want %q", msgs, want)
+	}
+	for i := range want {
+		if msgs[i] != want[i] {
+			t.Errorf("LimitDepth() link %d = %q, want %q", i, msgs[i], want[i])
+		}
+	}
+	if Code(root(limited)) != Unavailable {
+		t.Errorf("LimitDepth() summary coded %q, want the root's code", Code(root(limited)))
+	}
+	if got := LimitDepth(io.EOF, 3); got.Count() != 0 || message(got) != "EOF" {
+		t.Errorf("LimitDepth() = %v, want a short chain kept whole", got)
+	}
+}
@@ -0,0 +1,105 @@
+package eros
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Section - runs fn, a named phase of a larger piece of work, recovering whatever a
+// Check within it raises. The failure is wrapped with the section's name and how long
+// it ran for, in the fields section and elapsed, so a long function fails describing
+// the phase it was in. Every run is timed, failed or not, see SectionStats. nil if fn
+// completes
+//
+//	err := eros.Section("load", func() { ... }).
+//		WithCause(eros.Section("migrate", func() { ... }))
+func Section(name string, fn func()) (res *Error) {
+	start := time.Now()
+	defer func() {
+		if r := raised(recover()); r != nil {
+			handleRecovered(r, func(err *Error) {
+				elapsed := time.Since(start)
+				res = Wrapf(err, "section %s failed after %s", name, elapsed).
+					WithField("section", name).
+					WithField("elapsed", elapsed)
+			})
+		}
+		timeSection(name, time.Since(start), res != nil)
+	}()
+	fn()
+	return nil
+}
+
+// SectionStat - the runs of the sections of one name
+type SectionStat struct {
+	Name     string
+	Runs     uint64
+	Failures uint64
+	// Total - the time every run took, failed or not
+	Total time.Duration
+	// Max - the longest run
+	Max time.Duration
+}
+
+// sectionTimes - the runs of a section, for SectionStats
+type sectionTimes struct {
+	runs, failures uint64
+	total, max     int64
+}
+
+// sections - the *sectionTimes of each section run, by name
+var sections sync.Map
+
+// timeSection - records a run of the section name which took elapsed
+func timeSection(name string, elapsed time.Duration, failed bool) {
+	v, ok := sections.Load(name)
+	if !ok {
+		v, _ = sections.LoadOrStore(name, &sectionTimes{})
+	}
+	st := v.(*sectionTimes)
+	atomic.AddUint64(&st.runs, 1)
+	if failed {
+		atomic.AddUint64(&st.failures, 1)
+	}
+	atomic.AddInt64(&st.total, int64(elapsed))
+	for {
+		max := atomic.LoadInt64(&st.max)
+		if int64(elapsed) <= max || atomic.CompareAndSwapInt64(&st.max, max, int64(elapsed)) {
+			return
+		}
+	}
+}
+
+// SectionStats - the timings of every section run so far, by name; the most time
+// spent first
+func SectionStats() []SectionStat {
+	var stats []SectionStat
+	sections.Range(func(k, v interface{}) bool {
+		st := v.(*sectionTimes)
+		stats = append(stats, SectionStat{
+			Name:     k.(string),
+			Runs:     atomic.LoadUint64(&st.runs),
+			Failures: atomic.LoadUint64(&st.failures),
+			Total:    time.Duration(atomic.LoadInt64(&st.total)),
+			Max:      time.Duration(atomic.LoadInt64(&st.max)),
+		})
+		return true
+	})
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Total != stats[j].Total {
+			return stats[i].Total > stats[j].Total
+		}
+		return stats[i].Name < stats[j].Name
+	})
+	return stats
+}
+
+// ResetSectionStats - forgets the timings of every section run so far
+func ResetSectionStats() {
+	sections.Range(func(k, _ interface{}) bool {
+		sections.Delete(k)
+		return true
+	})
+}
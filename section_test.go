@@ -0,0 +1,53 @@
+package eros
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSectionStats(t *testing.T) {
+	ResetSectionStats()
+	defer ResetSectionStats()
+
+	for i := 0; i < 2; i++ {
+		Section("warm", func() { time.Sleep(time.Millisecond) })
+	}
+	Section("warm", func() { Check(errStepFailed) })
+
+	stats := SectionStats()
+	if len(stats) != 1 {
+		t.Fatalf("SectionStats() = %+v, want the one section", stats)
+	}
+	st := stats[0]
+	if st.Name != "warm" || st.Runs != 3 || st.Failures != 1 || st.Total < 2*time.Millisecond || st.Max < time.Millisecond {
+		t.Errorf("SectionStats() = %+v, want the successful runs timed too", st)
+	}
+}
+
+func TestSection(t *testing.T) {
+	if err := Section("ok", func() {}); err != nil {
+		t.Errorf("Section() = %v, want nil", err)
+	}
+	err := Section("load", func() {
+		time.Sleep(time.Millisecond)
+		Check(errStepFailed)
+		t.Errorf("Section() should stop at the failed Check")
+	})
+	if err == nil {
+		t.Fatal("Section() expected an error")
+	}
+	if !strings.HasPrefix(err.msg, "section load failed after ") || !Is(err, errStepFailed) {
+		t.Errorf("Section() = %v", err)
+	}
+	if err.Fields()["section"] != "load" || err.Fields()["elapsed"].(time.Duration) < time.Millisecond {
+		t.Errorf("Section() fields = %v", err.Fields())
+	}
+
+	defer func() {
+		if r := recover(); r != "not an error" {
+			t.Errorf("expected a panic that isn't an error to carry on, got %v", r)
+		}
+	}()
+	Section("boom", func() { panic("not an error") })
+}
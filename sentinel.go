@@ -0,0 +1,69 @@
+package eros
+
+import "sync"
+
+var (
+	sentinelsMu sync.RWMutex
+	sentinels   = map[string]*Error{}
+)
+
+// Sentinel - the sentinel named name, made and registered the first time it's asked
+// for, so packages agreeing on the name share it; typically kept in a package level
+// var. Is matches a sentinel by identity rather than by message: only the sentinel
+// itself, its copies (e.g. as raised by Check) and the links made by WrapSentinel
+// match it. Its message is its name
+//
+//	var ErrNoQuota = eros.Sentinel("billing.no_quota").WithCode(eros.Unavailable)
+func Sentinel(name string) *Error {
+	sentinelsMu.Lock()
+	defer sentinelsMu.Unlock()
+	if s, ok := sentinels[name]; ok {
+		return s
+	}
+	s := &Error{msg: name, sentinel: name}
+	sentinels[name] = s
+	return s
+}
+
+// LookupSentinel - the sentinel named name, if one was made by Sentinel
+func LookupSentinel(name string) (*Error, bool) {
+	sentinelsMu.RLock()
+	defer sentinelsMu.RUnlock()
+	s, ok := sentinels[name]
+	return s, ok
+}
+
+// WrapSentinel - wraps cause in a link carrying sentinel, so Is(err, sentinel) matches
+// while cause says what actually happened. The link has the sentinel's message, code,
+// severity, fault and HTTP status. A nil cause makes a fresh copy of the sentinel. A
+// nil sentinel has nothing to carry, cause is cast as CastOrWrap would; nil for nil
+//
+//	return eros.WrapSentinel(ErrNoQuota, err)
+func WrapSentinel(sentinel *Error, cause error) *Error {
+	if sentinel == nil {
+		if cause == nil {
+			return nil
+		}
+		return CastOrWrap(cause)
+	}
+	var e *Error
+	if cause == nil {
+		e = newError(sentinel.msg, 1)
+	} else {
+		e = wrap(cause, sentinel.msg, 1)
+	}
+	e.sentinel, e.code, e.severity = sentinel.sentinel, sentinel.code, sentinel.severity
+	e.fault, e.status = sentinel.fault, sentinel.status
+	if cause != nil {
+		countError(e)
+	}
+	return e
+}
+
+// linkSentinel - the name of the sentinel this one link carries, if any
+func linkSentinel(err error) string {
+	if e := link(err); e != nil {
+		return e.sentinel
+	}
+	return ""
+}
@@ -0,0 +1,50 @@
+package eros
+
+import (
+	"io"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestSentinel(t *testing.T) {
+	noQuota := Sentinel("eros_test.no_quota").WithCode(Unavailable)
+	if again := Sentinel("eros_test.no_quota"); again != noQuota {
+		t.Errorf("Sentinel() made %p again, want the registered %p", again, noQuota)
+	}
+	if s, ok := LookupSentinel("eros_test.no_quota"); !ok || s != noQuota {
+		t.Errorf("LookupSentinel() = %v, %v, want the registered sentinel", s, ok)
+	}
+
+	err := Wrap(WrapSentinel(noQuota, io.EOF), "billing")
+	if !Is(err, noQuota) || !errors.Is(err, noQuota) || !Is(err, io.EOF) {
+		t.Errorf("expected %v to match both the sentinel and its cause", err)
+	}
+	if Code(err) != Unavailable {
+		t.Errorf("Code() = %q, want the sentinel's", Code(err))
+	}
+	if Is(New("eros_test.no_quota"), noQuota) {
+		t.Errorf("expected an error of the same message not to match the sentinel")
+	}
+	if Is(Sentinel("eros_test.other"), noQuota) {
+		t.Errorf("expected another sentinel not to match")
+	}
+
+	var raised *Error
+	func() {
+		defer ErrorHandler(func(err *Error) { raised = err })()
+		Check(noQuota)
+	}()
+	if !Is(raised, noQuota) {
+		t.Errorf("expected the sentinel to match once raised by Check")
+	}
+}
+
+func TestWrapSentinelNil(t *testing.T) {
+	if WrapSentinel(nil, nil) != nil {
+		t.Errorf("WrapSentinel(nil, nil) should be nil")
+	}
+	if got := WrapSentinel(nil, io.EOF); !Is(got, io.EOF) {
+		t.Errorf("WrapSentinel(nil, io.EOF) = %v, want the cause kept", got)
+	}
+}
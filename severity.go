@@ -0,0 +1,99 @@
+package eros
+
+// Severity - how serious an error is. The zero value, Normal, is an ordinary failure;
+// anything below it is something we carried on from, anything above it means the
+// process itself may be in trouble.
+type Severity int
+
+const (
+	// Info - worth knowing about, nothing actually failed
+	Info Severity = iota - 2
+	// Warning - something failed but we failed through it
+	Warning
+	// Normal - an ordinary failure, the default for every error
+	Normal
+	// Fatal - a failure the process may not be able to recover from
+	Fatal
+)
+
+// String - implement the Stringer interface
+func (s Severity) String() string {
+	switch s {
+	case Info:
+		return "info"
+	case Warning:
+		return "warning"
+	case Normal:
+		return "error"
+	case Fatal:
+		return "fatal"
+	}
+	return "unknown"
+}
+
+// WithSeverity - sets the severity of this link in the chain. This is nil safe
+func (e *Error) WithSeverity(s Severity) *Error {
+	if e != nil {
+		e.severity = s
+	}
+	return e
+}
+
+// Severity - the highest severity set anywhere in the chain
+func (e *Error) Severity() Severity {
+	if e == nil {
+		return Normal
+	}
+	return SeverityOf(e)
+}
+
+// SeverityOf - the highest severity set anywhere in err's chain. Links without a
+// severity (including anything that isn't an eros Error) don't count, so a chain
+// with nothing set has the severity declared for its code (see RegisterCode),
+// otherwise Normal
+func SeverityOf(err error) Severity {
+	sev, set := Normal, false
+	walk(err, func(e error) bool {
+		if l := link(e); l != nil && l.severity != Normal && (!set || l.severity > sev) {
+			sev, set = l.severity, true
+		}
+		return true
+	})
+	if info, ok := LookupCode(Code(err)); ok && !set {
+		sev = info.Severity
+	}
+	return sev
+}
+
+// link - the eros Error behind err, whether it's held by pointer or by value.
+// Returns nil for any other error
+func link(err error) *Error {
+	switch e := err.(type) {
+	case *Error:
+		return e
+	case Error:
+		return &e
+	}
+	return nil
+}
+
+// CheckWarn - Check, for a failure the caller can carry on from; err is tagged Warning
+// and handed to the observers as Warned, nothing is raised. That's all there is to
+// handling it, so it's never reported as Unhandled
+func CheckWarn(err error, mesgs ...string) {
+	if err != nil {
+		countCheck(1)
+		e := CastOrWrap(err, mesgs...).WithSeverity(Warning)
+		mark(e)
+		notify(Warned, e)
+	}
+}
+
+// CheckFatal - Check, for a failure the process may not recover from; err is tagged
+// Fatal before it's raised, see CaptureGoroutines
+func CheckFatal(err error, mesgs ...string) {
+	if err != nil {
+		countCheck(1)
+		raise(CastOrWrap(err, mesgs...).WithSeverity(Fatal))
+	}
+}
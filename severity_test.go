@@ -0,0 +1,76 @@
+package eros
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestSeverityOf(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want Severity
+	}{
+		{
+			"Test nothing set is Normal",
+			Wrap(errors.New("root"), "outer"),
+			Normal,
+		},
+		{
+			"Test a warning is found deep in the chain",
+			errors.Wrap(New("inner").WithSeverity(Warning), "outer"),
+			Warning,
+		},
+		{
+			"Test the highest severity wins",
+			Wrap(New("inner").WithSeverity(Fatal), "outer").WithSeverity(Warning),
+			Fatal,
+		},
+		{
+			"Test a fatal cause below a WithCause is found",
+			Wrap(New("x").WithSeverity(Fatal), "outer").WithCause(New("other")),
+			Fatal,
+		},
+		{
+			"Test a non eros error is Normal",
+			errors.New("plain"),
+			Normal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SeverityOf(tt.err); got != tt.want {
+				t.Errorf("SeverityOf() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckWarn(t *testing.T) {
+	var warned []*Error
+	remove := Observe(func(ev Event, err *Error) {
+		if ev == Warned {
+			warned = append(warned, err)
+		}
+	})
+	defer remove()
+
+	CheckWarn(nil)
+	CheckWarn(errStepFailed, "cache refresh")
+	if len(warned) != 1 || warned[0].Severity() != Warning || !Is(warned[0], errStepFailed) {
+		t.Errorf("expected the one failure reported as a Warning, got %v", warned)
+	}
+}
+
+func TestCheckFatal(t *testing.T) {
+	var got *Error
+	func() {
+		defer ErrorHandler(func(err *Error) { got = err })()
+		CheckFatal(nil)
+		CheckFatal(errStepFailed)
+	}()
+	if got.Severity() != Fatal || !Is(got, errStepFailed) {
+		t.Errorf("CheckFatal() raised %v with severity %v, want Fatal", got, got.Severity())
+	}
+}
@@ -0,0 +1,86 @@
+package eros
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Shutdown - coordinates stopping the components of a daemon. Components register a
+// stop func as they start and Run stops them all, most recently registered first,
+// collecting every failure into one chain. The zero value is ready to use
+type Shutdown struct {
+	mu         sync.Mutex
+	components []component
+}
+
+// component - a registered stop func
+type component struct {
+	name    string
+	timeout time.Duration
+	stop    func(ctx context.Context) error
+}
+
+// Register - adds a component to stop, which gets at most timeout to do so; a timeout
+// of 0 or less is no limit of its own, only that of the ctx given to Run. The stop
+// func has the same shape as http.Server.Shutdown
+func (s *Shutdown) Register(name string, timeout time.Duration, stop func(ctx context.Context) error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.components = append(s.components, component{name, timeout, stop})
+}
+
+// Run - stops every component in reverse order of registration, each under its own
+// timeout derived from ctx. A component that fails, panics or overruns its timeout is
+// wrapped with its name (and a component field) and chained into the result, which is
+// nil when everything stopped cleanly
+func (s *Shutdown) Run(ctx context.Context) (res *Error) {
+	s.mu.Lock()
+	components := append([]component(nil), s.components...)
+	s.mu.Unlock()
+
+	for i := len(components) - 1; i >= 0; i-- {
+		c := components[i]
+		if err := c.run(ctx); err != nil {
+			res = res.WithCause(Wrapf(err, "failed to stop %s", c.name).WithField("component", c.name))
+		}
+	}
+	return
+}
+
+// run - stops the component, giving up on it once its timeout, if any, or ctx is up
+func (c component) run(ctx context.Context) error {
+	cancel := func() {}
+	if c.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+	}
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := raised(recover()); r != nil {
+				if err, ok := unchecked(r).(error); ok {
+					done <- CastOrWrap(err, "panicked")
+				} else {
+					done <- Newf("panicked: %v", r)
+				}
+			}
+		}()
+		// in panic free mode a failed Check returns, its error is sent by the defer
+		if err := c.stop(ctx); Pending() == nil {
+			done <- err
+		}
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		if c.timeout <= 0 {
+			return Wrap(ctx.Err(), "gave up")
+		}
+		return Wrap(ctx.Err(), fmt.Sprintf("gave up after %s", c.timeout))
+	}
+}
@@ -0,0 +1,89 @@
+package eros
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestShutdown(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		order []string
+	)
+	stopping := func(name string) {
+		mu.Lock()
+		defer mu.Unlock()
+		order = append(order, name)
+	}
+	hung := make(chan struct{})
+	defer close(hung)
+
+	var s Shutdown
+	s.Register("db", time.Second, func(ctx context.Context) error {
+		stopping("db")
+		return New("connections still open")
+	})
+	s.Register("cache", time.Second, func(ctx context.Context) error {
+		stopping("cache")
+		return nil
+	})
+	s.Register("worker", time.Second, func(ctx context.Context) error {
+		stopping("worker")
+		panic("worker exploded")
+	})
+	s.Register("server", 10*time.Millisecond, func(ctx context.Context) error {
+		stopping("server")
+		<-hung
+		return nil
+	})
+
+	err := s.Run(context.Background())
+	mu.Lock()
+	defer mu.Unlock()
+	if got := []string{"server", "worker", "cache", "db"}; len(order) != 4 || order[0] != got[0] || order[3] != got[3] {
+		t.Errorf("stopped in order %v, want %v", order, got)
+	}
+	var failed []string
+	for e := error(err); e != nil; e = Unwrap(e) {
+		if l := link(e); l != nil && l.fields["component"] != nil {
+			failed = append(failed, l.fields["component"].(string))
+		}
+	}
+	if len(failed) != 3 {
+		t.Errorf("expected db, worker and server in the chain, got %v", failed)
+	}
+	if !Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the server's timeout in the chain, got %v", err)
+	}
+
+	var clean Shutdown
+	clean.Register("noop", time.Second, func(ctx context.Context) error { return nil })
+	if err := clean.Run(context.Background()); err != nil {
+		t.Errorf("expected a clean shutdown, got %v", err)
+	}
+}
+
+func TestShutdownNoTimeout(t *testing.T) {
+	var s Shutdown
+	s.Register("db", 0, func(ctx context.Context) error {
+		time.Sleep(time.Millisecond)
+		return ctx.Err()
+	})
+	if err := s.Run(context.Background()); err != nil {
+		t.Errorf("expected a component without a timeout of its own to stop, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	var hung Shutdown
+	hung.Register("server", 0, func(ctx context.Context) error {
+		<-ctx.Done()
+		time.Sleep(time.Second)
+		return nil
+	})
+	if err := hung.Run(ctx); !Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected Run's ctx to still bound the component, got %v", err)
+	}
+}
@@ -0,0 +1,109 @@
+package eros
+
+import "unicode"
+
+// SimilarTo - whether a and b failed in much the same way, for suppressing near
+// duplicate alerts that Fingerprint, being exact, tells apart. The chains are compared
+// link by link, outermost first; each pair scores by the normalized edit distance of
+// their templates (the format of Newf and Wrapf, otherwise the message with runs of
+// digits taken as one), nothing if both are coded and the codes differ. a and b are
+// similar if the average over the longer chain is at least threshold, between 0 and
+// 1; 1 takes chains identical bar variable data. Two nils are similar, nil and an
+// error aren't
+func SimilarTo(a, b error, threshold float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return similarity(a, b) >= threshold
+}
+
+// similarity - how alike a's and b's chains are, from 0 to 1
+func similarity(a, b error) float64 {
+	la, lb := linkTemplates(a), linkTemplates(b)
+	if len(la) < len(lb) {
+		la, lb = lb, la
+	}
+	total := 0.0
+	for i := range lb {
+		if la[i].code != "" && lb[i].code != "" && la[i].code != lb[i].code {
+			continue
+		}
+		total += 1 - normalizedDistance(la[i].text, lb[i].text)
+	}
+	return total / float64(len(la))
+}
+
+// linkTemplate - what a link of a chain is compared by
+type linkTemplate struct {
+	code string
+	text []rune
+}
+
+// linkTemplates - the template of every link in err's chain, in the order walk visits
+func linkTemplates(err error) []linkTemplate {
+	var res []linkTemplate
+	walk(err, func(l error) bool {
+		t := linkTemplate{code: linkCode(l)}
+		if e := link(l); e != nil && e.format != "" {
+			t.text = []rune(e.format)
+		} else {
+			t.text = collapseDigits(message(l))
+		}
+		res = append(res, t)
+		return true
+	})
+	return res
+}
+
+// collapseDigits - s with every run of digits replaced by a single '#'
+func collapseDigits(s string) []rune {
+	var res []rune
+	for _, r := range s {
+		if unicode.IsDigit(r) {
+			if len(res) > 0 && res[len(res)-1] == '#' {
+				continue
+			}
+			r = '#'
+		}
+		res = append(res, r)
+	}
+	return res
+}
+
+// normalizedDistance - the Levenshtein distance between a and b over the length of
+// the longer, from 0 (the same) to 1 (nothing in common)
+func normalizedDistance(a, b []rune) float64 {
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	if len(a) == 0 {
+		return 0
+	}
+	prev, cur := make([]int, len(b)+1), make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min3(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return float64(prev[len(b)]) / float64(len(a))
+}
+
+// min3 - the least of a, b and c
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
@@ -0,0 +1,87 @@
+package eros
+
+import (
+	"io"
+	"testing"
+)
+
+func TestSimilarTo(t *testing.T) {
+	tests := []struct {
+		name      string
+		a, b      error
+		threshold float64
+		want      bool
+	}{
+		{
+			"Test variable data in a message",
+			Wrap(New("user 1234 not found"), "loading profile"),
+			Wrap(New("user 98 not found"), "loading profile"),
+			1,
+			true,
+		},
+		{
+			"Test the same template",
+			Wrapf(io.EOF, "reading %s", "users.csv"),
+			Wrapf(io.EOF, "reading %s", "orders.csv"),
+			1,
+			true,
+		},
+		{
+			"Test slightly reworded",
+			Wrap(io.EOF, "failed to read the config"),
+			Wrap(io.EOF, "failed reading the config"),
+			0.8,
+			true,
+		},
+		{
+			"Test different codes",
+			New("lookup failed").WithCode(NotFound),
+			New("lookup failed").WithCode(Unavailable),
+			0.5,
+			false,
+		},
+		{
+			"Test a chain with an extra link",
+			Wrap(Wrap(io.EOF, "reading"), "syncing"),
+			Wrap(io.EOF, "reading"),
+			0.9,
+			false,
+		},
+		{
+			"Test nothing alike",
+			New("disk full"),
+			New("permission denied for bob"),
+			0.5,
+			false,
+		},
+		{
+			"Test nils",
+			nil,
+			nil,
+			1,
+			true,
+		},
+		{
+			"Test nil and an error",
+			nil,
+			io.EOF,
+			0,
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SimilarTo(tt.a, tt.b, tt.threshold); got != tt.want {
+				t.Errorf("SimilarTo() = %v, want %v (similarity %v)", got, tt.want, similarityOf(tt.a, tt.b))
+			}
+		})
+	}
+}
+
+// similarityOf - similarity, nil safe, for failure messages
+func similarityOf(a, b error) float64 {
+	if a == nil || b == nil {
+		return 0
+	}
+	return similarity(a, b)
+}
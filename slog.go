@@ -0,0 +1,95 @@
+//go:build go1.21
+
+package eros
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+)
+
+// Attrs - err flattened into attributes, for logging it with slog wherever it's at
+// hand;
+//
+//	slog.LogAttrs(ctx, slog.LevelError, "sync failed", eros.Attrs(err)...)
+//
+// There's the message, the code and every code along the chain, the fields merged as
+// AllFields does (in a "fields" group), the root cause, the number of links and the
+// fingerprint. nil for nil
+func Attrs(err error) []slog.Attr {
+	if err == nil {
+		return nil
+	}
+	attrs := []slog.Attr{slog.String("error", message(err))}
+	if code := Code(err); code != "" {
+		attrs = append(attrs, slog.String("code", code))
+	}
+	var codes []string
+	depth := 0
+	walk(err, func(l error) bool {
+		depth++
+		if c := linkCode(l); c != "" {
+			codes = append(codes, c)
+		}
+		return true
+	})
+	if len(codes) > 1 {
+		attrs = append(attrs, slog.Any("codes", codes))
+	}
+	if fields := allFields(err); len(fields) > 0 {
+		keys := make([]string, 0, len(fields))
+		for k := range fields {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		group := make([]any, len(keys))
+		for i, k := range keys {
+			group[i] = slog.Any(k, fields[k])
+		}
+		attrs = append(attrs, slog.Group("fields", group...))
+	}
+	return append(attrs,
+		slog.String("root_cause", message(root(err))),
+		slog.Int("depth", depth),
+		slog.String("fingerprint", Fingerprint(err)),
+	)
+}
+
+// LogValue - implement slog.LogValuer, so an Error logged as any other value;
+//
+//	slog.Error("sync failed", "err", err)
+//
+// comes out as a group of the attributes Attrs gives. Logging an error handles it
+func (e *Error) LogValue() slog.Value {
+	if e == nil {
+		return slog.AnyValue(nil)
+	}
+	mark(e)
+	return slog.GroupValue(Attrs(e)...)
+}
+
+// SlogLogger - logger as a Logger for Bind; Info, Warning and Normal errors are
+// logged at the levels of the same names (Normal as Error), Fatal ones above Error.
+// The error goes in as the attributes Attrs gives
+func SlogLogger(logger *slog.Logger) Logger {
+	return slogLogger{logger}
+}
+
+// slogLogger - a Logger logging to slog
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+// LogError - implement Logger
+func (l slogLogger) LogError(sev Severity, msg string, err *Error) {
+	level := slog.LevelError
+	switch sev {
+	case Info:
+		level = slog.LevelInfo
+	case Warning:
+		level = slog.LevelWarn
+	case Fatal:
+		level = slog.LevelError + 4
+	}
+	l.logger.LogAttrs(context.Background(), level, msg, Attrs(err)...)
+}
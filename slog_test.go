@@ -0,0 +1,73 @@
+//go:build go1.21
+
+package eros
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestAttrs(t *testing.T) {
+	if Attrs(nil) != nil {
+		t.Errorf("Attrs(nil) should be nil")
+	}
+	err := Wrap(Wrap(io.EOF, "reading row").WithCode("TEST_DB").WithField("table", "users"), "loading user").
+		WithCode(NotFound).WithField("id", 7)
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	logger.LogAttrs(context.Background(), slog.LevelError, "failed", Attrs(err)...)
+	got := strings.TrimSpace(buf.String())
+	want := `level=ERROR msg=failed error="loading user" code=NOT_FOUND codes="[NOT_FOUND TEST_DB]" ` +
+		`fields.id=7 fields.table=users root_cause=EOF depth=3 fingerprint=` + Fingerprint(err)
+	if got != want {
+		t.Errorf("Attrs() logged\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestLogValue(t *testing.T) {
+	err := Wrap(io.EOF, "reading row").WithCode(NotFound)
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	logger.Error("failed", "err", err)
+
+	var got struct {
+		Err map[string]interface{} `json:"err"`
+	}
+	if jerr := json.Unmarshal(buf.Bytes(), &got); jerr != nil {
+		t.Fatal(jerr)
+	}
+	if got.Err["error"] != "reading row" || got.Err["code"] != NotFound || got.Err["root_cause"] != "EOF" {
+		t.Errorf("LogValue() logged %s, want the Attrs grouped under err", buf.String())
+	}
+
+	var nilErr *Error
+	if v := nilErr.LogValue(); v.Any() != nil {
+		t.Errorf("LogValue() = %v for nil, want nil", v)
+	}
+}
+
+func TestSlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+	log := Bind(SlogLogger(slog.New(slog.NewTextHandler(&buf, nil))))
+	log.Wrap(New("stale cache").WithSeverity(Warning), "serving stale")
+	_ = log.Wrap(New("out of memory").WithSeverity(Fatal), "allocating")
+
+	got := buf.String()
+	if !strings.Contains(got, `level=WARN msg="serving stale"`) || !strings.Contains(got, `level=ERROR+4 msg=allocating`) {
+		t.Errorf("SlogLogger() logged\n%s", got)
+	}
+}
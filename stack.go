@@ -0,0 +1,185 @@
+package eros
+
+import (
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// maxDepth - the most frames we capture for a single stack
+const maxDepth = 32
+
+// callers - the program counters of our caller's stack, skipping skip frames above
+// the caller of callers. Resolving them into frames is left until someone asks
+func callers(skip int) []uintptr {
+	var pcs [maxDepth]uintptr
+	n := runtime.Callers(skip+2, pcs[:])
+	return pcs[:n:n]
+}
+
+// CaptureStacks - when on, New, Newf, Wrap and Wrapf capture the stack they are
+// called from; Wrap only when the chain doesn't carry one already. Off by default,
+// so hot paths don't pay for it, WithStack attaches one at chosen boundaries instead
+func CaptureStacks(on bool) Setting {
+	return func(c *config) {
+		c.stacks = on
+	}
+}
+
+// withCallers - attaches the stack skip frames above the caller of withCallers to e,
+// when CaptureStacks is on
+func withCallers(e *Error, skip int) *Error {
+	if conf().stacks {
+		e.stack = callers(skip + 1)
+	}
+	return e
+}
+
+// WithStack - attaches the stack at the call point to err, for when a trace is only
+// worth its cost at a meaningful boundary. It's a noop if err's chain already has a
+// stack, ours or anyone's. Errors that aren't ours are CastOrWrap'd first
+func WithStack(err error) *Error {
+	if err == nil {
+		return nil
+	}
+	e := link(err)
+	if e == nil {
+		e = CastOrWrap(err)
+	}
+	if !hasStack(err) {
+		e.stack = callers(1)
+	}
+	return e
+}
+
+// StackTrace - the frames of the stack captured for this link, nil if none was
+func (e *Error) StackTrace() []runtime.Frame {
+	if e == nil || len(e.stack) == 0 {
+		return nil
+	}
+	frames := make([]runtime.Frame, 0, len(e.stack))
+	iter := runtime.CallersFrames(e.stack)
+	for {
+		f, more := iter.Next()
+		frames = append(frames, f)
+		if !more {
+			return frames
+		}
+	}
+}
+
+// Frame - a frame of a captured stack
+type Frame = runtime.Frame
+
+// Frames - the frames of a captured stack, innermost first
+type Frames []Frame
+
+// Frames - the stack captured for this link, nil if none was. StackTrace as Frames,
+// to be filtered and cut down for sinks and renderers
+func (e *Error) Frames() Frames {
+	return e.StackTrace()
+}
+
+// FilterPrefix - only the frames of functions whose package path starts with
+// pkgPrefix, e.g. the module's own
+func (f Frames) FilterPrefix(pkgPrefix string) Frames {
+	var res Frames
+	for _, frame := range f {
+		if strings.HasPrefix(frame.Function, pkgPrefix) {
+			res = append(res, frame)
+		}
+	}
+	return res
+}
+
+// Top - the innermost n frames, all of them if there aren't as many
+func (f Frames) Top(n int) Frames {
+	if n < len(f) {
+		return f[:n:n]
+	}
+	return f
+}
+
+// Trace - one coherent trace for err's whole chain, rather than the partial ones
+// captured along it by us, pkg/errors or whatever else exposes a StackTrace method.
+// The deepest stack, where it all started, comes first; frames from stacks further
+// out which aren't in it already follow, e.g. where work was handed to another
+// goroutine. Frames inside eros itself and the runtime's panic machinery are trimmed.
+// nil if nothing in the chain captured a stack
+func Trace(err error) Frames {
+	var stacks []Frames
+	walk(err, func(l error) bool {
+		if frames := linkFrames(l); len(frames) > 0 {
+			stacks = append(stacks, frames)
+		}
+		return true
+	})
+	var res Frames
+	seen := map[Frame]bool{}
+	for i := len(stacks) - 1; i >= 0; i-- {
+		var add Frames
+		for _, f := range stacks[i] {
+			// told apart by where they are, the same call may be captured more than once
+			f.PC, f.Entry, f.Func = 0, 0, nil
+			if seen[f] || internalFrame(f, len(add) == 0) {
+				continue
+			}
+			add = append(add, f)
+		}
+		for _, f := range add {
+			seen[f] = true
+		}
+		res = append(res, add...)
+	}
+	return res
+}
+
+// ourPackage - the prefix of the functions of eros itself
+var ourPackage = reflect.TypeOf(Error{}).PkgPath() + "."
+
+// internalFrame - whether f is noise in a trace; inside eros (its tests aside), or the
+// runtime panicking at the top of the stack
+func internalFrame(f Frame, top bool) bool {
+	if strings.HasPrefix(f.Function, ourPackage) && !strings.HasSuffix(f.File, "_test.go") {
+		return true
+	}
+	return top && strings.HasPrefix(f.Function, "runtime.")
+}
+
+// linkFrames - the stack this one link of a chain captured; ours, or by a StackTrace
+// method returning frames or program counters (as pkg/errors does)
+func linkFrames(err error) Frames {
+	if e := link(err); e != nil {
+		return e.Frames()
+	}
+	m := reflect.ValueOf(err).MethodByName("StackTrace")
+	if !m.IsValid() || m.Type().NumIn() != 0 || m.Type().NumOut() != 1 {
+		return nil
+	}
+	switch st := m.Call(nil)[0]; {
+	case st.Type() == reflect.TypeOf(Frames(nil)), st.Type() == reflect.TypeOf([]runtime.Frame(nil)):
+		return st.Convert(reflect.TypeOf(Frames(nil))).Interface().(Frames)
+	case st.Kind() == reflect.Slice && st.Type().Elem().Kind() == reflect.Uintptr:
+		pcs := make([]uintptr, st.Len())
+		for i := range pcs {
+			pcs[i] = uintptr(st.Index(i).Uint())
+		}
+		return (&Error{stack: pcs}).Frames()
+	}
+	return nil
+}
+
+// hasStack - whether any link in err's chain carries a stack. Errors that aren't
+// ours count when they have a StackTrace method, whatever it returns (pkg/errors)
+func hasStack(err error) bool {
+	found := false
+	walk(err, func(l error) bool {
+		if e := link(l); e != nil {
+			found = len(e.stack) > 0
+		} else {
+			found = reflect.ValueOf(l).MethodByName("StackTrace").IsValid()
+		}
+		return !found
+	})
+	return found
+}
@@ -0,0 +1,120 @@
+package eros
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestWithStack(t *testing.T) {
+	e := New("no stack yet")
+	if e.StackTrace() != nil {
+		t.Fatalf("expected no stack before WithStack")
+	}
+	got := WithStack(e)
+	if got != e {
+		t.Errorf("expected WithStack to attach to the same error")
+	}
+	frames := got.StackTrace()
+	if len(frames) == 0 || !strings.HasSuffix(frames[0].Function, "eros.TestWithStack") {
+		t.Fatalf("expected the stack to start at the call point, got %v", frames)
+	}
+
+	before := got.StackTrace()
+	if again := WithStack(got); !reflect.DeepEqual(again.StackTrace(), before) {
+		t.Errorf("expected a second WithStack to be a noop")
+	}
+	if foreign := WithStack(errors.New("pkg/errors has its own")); foreign.StackTrace() != nil {
+		t.Errorf("expected no stack on top of a pkg/errors stack")
+	}
+	if WithStack(nil) != nil {
+		t.Errorf("WithStack(nil) should be nil")
+	}
+}
+
+func TestCaptureStacks(t *testing.T) {
+	if New("off").StackTrace() != nil {
+		t.Fatalf("expected no stack with capture off")
+	}
+	Configure(CaptureStacks(true))
+	defer Configure(CaptureStacks(false))
+
+	startsHere := func(name string, e *Error) {
+		t.Helper()
+		frames := e.StackTrace()
+		if len(frames) == 0 || !strings.HasSuffix(frames[0].Function, "eros.TestCaptureStacks") {
+			t.Errorf("expected the stack of %s to start at the call point, got %v", name, frames)
+		}
+	}
+	root := New("root")
+	startsHere("New", root)
+	startsHere("Newf", Newf("root %d", 1))
+	startsHere("Wrap", Wrap(io.EOF, "wrapped"))
+	startsHere("Wrapf", Wrapf(io.EOF, "wrapped %d", 1))
+
+	if wrapped := Wrap(root, "wrapped"); wrapped.StackTrace() != nil {
+		t.Errorf("expected Wrap to leave the stack to the root")
+	}
+	if foreign := Wrap(errors.New("pkg/errors has its own"), "wrapped"); foreign.StackTrace() != nil {
+		t.Errorf("expected no stack on top of a pkg/errors stack")
+	}
+}
+
+func TestFrames(t *testing.T) {
+	err := WithStack(io.EOF)
+	frames := err.Frames()
+	if len(frames) < 2 {
+		t.Fatalf("expected a captured stack, got %v", frames)
+	}
+	own := frames.FilterPrefix("github.com/dawenga/eros.")
+	if len(own) != 1 || !strings.HasSuffix(own[0].Function, "TestFrames") {
+		t.Errorf("FilterPrefix() = %v, want only the frame of this package", own)
+	}
+	if top := frames.Top(1); len(top) != 1 || top[0] != frames[0] {
+		t.Errorf("Top(1) = %v, want the innermost frame", top)
+	}
+	if all := frames.Top(len(frames) + 1); len(all) != len(frames) {
+		t.Errorf("Top() = %v, want every frame when asked for more", all)
+	}
+	if New("no stack").Frames() != nil {
+		t.Errorf("expected no frames without a captured stack")
+	}
+}
+
+func TestTrace(t *testing.T) {
+	if Trace(New("no stack")) != nil {
+		t.Errorf("expected no trace without a captured stack")
+	}
+
+	inner := make(chan error)
+	go func() {
+		inner <- errors.New("pkg/errors captured this one")
+	}()
+	err := WithStack(New("batch failed")).WithCause(<-inner)
+
+	trace := Trace(err)
+	if len(trace) == 0 || !strings.Contains(trace[0].Function, "TestTrace.func") {
+		t.Fatalf("expected the trace to start where the innermost stack was captured, got %v", trace)
+	}
+	found := false
+	for _, f := range trace {
+		if strings.HasSuffix(f.Function, "eros.TestTrace") {
+			found = true
+		}
+		if internalFrame(f, false) {
+			t.Errorf("expected frames inside eros trimmed, got %v", f)
+		}
+	}
+	if !found {
+		t.Errorf("expected the outer stack's frames to follow, got %v", trace)
+	}
+
+	verbose := fmt.Sprintf("%+v", err)
+	if n := strings.Count(verbose, "TestTrace.func"); n != 1 {
+		t.Errorf("expected %%+v to print one trace, got %q", verbose)
+	}
+}
@@ -0,0 +1,159 @@
+package eros
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Store - keeps error chains across restarts, so when a process is crash looping it
+// can be seen what it was failing on before it died
+type Store interface {
+	// Save - keeps err
+	Save(err *Error) error
+	// LoadRecent - the errors kept, oldest first
+	LoadRecent() ([]*Error, error)
+}
+
+// Persist - saves every error a Check raises, and every one handed to Report, to s;
+// a Check failing on the way to taking the process down is saved before it panics.
+// Failures to save are dropped, there's nowhere better to report them. Returns a
+// func which stops it
+func Persist(s Store) (remove func()) {
+	return Observe(func(ev Event, err *Error) {
+		if ev == Raised || ev == Reported {
+			_ = s.Save(err)
+		}
+	})
+}
+
+// defaultKeep - how many chains a FileStore keeps unless told otherwise
+const defaultKeep = 100
+
+// FileStore - a Store keeping the most recent chains in a file, a line of JSON each
+// (see MarshalJSON)
+type FileStore struct {
+	mu    sync.Mutex
+	path  string
+	keep  int
+	lines int // in the file, -1 until it's been read
+}
+
+// NewFileStore - a FileStore keeping the keep most recent chains in the file at path,
+// which is created as needed. A keep of 0 or less keeps 100
+func NewFileStore(path string, keep int) *FileStore {
+	if keep <= 0 {
+		keep = defaultKeep
+	}
+	return &FileStore{path: path, keep: keep, lines: -1}
+}
+
+// Save - implement Store. err is appended to the file, so a save costs the same
+// however many chains are kept; once the file holds twice keep it's compacted down to
+// the keep most recent, replaced rather than written in place so a crash half way
+// through leaves the chains that were there
+func (s *FileStore) Save(err *Error) error {
+	if err == nil {
+		return nil
+	}
+	line, jerr := json.Marshal(err)
+	if jerr != nil {
+		return Wrap(jerr, "failed to encode the error")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.lines < 0 {
+		lines, rerr := s.read()
+		if rerr != nil {
+			return rerr
+		}
+		s.lines = len(lines)
+	}
+	if s.lines+1 >= 2*s.keep {
+		return s.compact(line)
+	}
+	f, oerr := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if oerr != nil {
+		return Wrapf(oerr, "failed to save to %s", s.path)
+	}
+	_, werr := f.Write(append(line, '\n'))
+	if cerr := f.Close(); werr == nil {
+		werr = cerr
+	}
+	if werr != nil {
+		return Wrapf(werr, "failed to save to %s", s.path)
+	}
+	s.lines++
+	return nil
+}
+
+// compact - replaces the file with its keep most recent chains, line the last of them
+func (s *FileStore) compact(line []byte) error {
+	lines, rerr := s.read()
+	if rerr != nil {
+		return rerr
+	}
+	lines = append(lines, line)
+	if len(lines) > s.keep {
+		lines = lines[len(lines)-s.keep:]
+	}
+	tmp, terr := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if terr != nil {
+		return Wrapf(terr, "failed to save to %s", s.path)
+	}
+	defer os.Remove(tmp.Name())
+	_, werr := tmp.Write(append(bytes.Join(lines, []byte("\n")), '\n'))
+	if cerr := tmp.Close(); werr == nil {
+		werr = cerr
+	}
+	if werr != nil {
+		return Wrapf(werr, "failed to save to %s", s.path)
+	}
+	if rerr := os.Rename(tmp.Name(), s.path); rerr != nil {
+		return Wrapf(rerr, "failed to save to %s", s.path)
+	}
+	s.lines = len(lines)
+	return nil
+}
+
+// LoadRecent - implement Store. Nothing if nothing was saved yet; lines which can't
+// be decoded, e.g. written by something else, are skipped
+func (s *FileStore) LoadRecent() ([]*Error, error) {
+	s.mu.Lock()
+	lines, err := s.read()
+	s.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) > s.keep {
+		lines = lines[len(lines)-s.keep:]
+	}
+	var res []*Error
+	for _, line := range lines {
+		e := &Error{}
+		if json.Unmarshal(line, e) == nil {
+			res = append(res, e)
+		}
+	}
+	return res, nil
+}
+
+// read - the lines of the file, none if it doesn't exist yet
+func (s *FileStore) read() ([][]byte, error) {
+	b, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, Wrapf(err, "failed to load from %s", s.path)
+	}
+	var lines [][]byte
+	for _, line := range bytes.Split(b, []byte("\n")) {
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			lines = append(lines, line)
+		}
+	}
+	return lines, nil
+}
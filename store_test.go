@@ -0,0 +1,71 @@
+package eros
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.jsonl")
+	s := NewFileStore(path, 2)
+
+	if recent, err := s.LoadRecent(); err != nil || len(recent) != 0 {
+		t.Fatalf("LoadRecent() = %v, %v, want nothing before anything was saved", recent, err)
+	}
+
+	remove := Persist(s)
+	func() {
+		defer ErrorHandler(func(*Error) {})()
+		Check(Wrap(New("disk full").WithCode(Unavailable), "writing segment"))
+	}()
+	Report(New("replica lagging"))
+	Report(New("replica caught up"))
+	remove()
+	Report(New("not persisted"))
+
+	// as a restarted process would
+	recent, err := NewFileStore(path, 2).LoadRecent()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recent) != 2 || recent[0].msg != "replica lagging" || recent[1].msg != "replica caught up" {
+		t.Errorf("LoadRecent() = %v, want the two most recent, oldest first", recent)
+	}
+
+	if err := os.WriteFile(path, []byte("not json\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Save(Wrap(New("disk full").WithCode(Unavailable), "writing segment")); err != nil {
+		t.Fatal(err)
+	}
+	recent, _ = s.LoadRecent()
+	if len(recent) != 1 || Code(recent[0]) != Unavailable || recent[0].Root().(*Error).msg != "disk full" {
+		t.Errorf("LoadRecent() = %v, want the whole chain back, garbage skipped", recent)
+	}
+}
+
+func TestFileStoreBounded(t *testing.T) {
+	if s := NewFileStore("errors.jsonl", 0); s.keep != defaultKeep {
+		t.Errorf("NewFileStore() keep = %d, want the default for 0", s.keep)
+	}
+
+	path := filepath.Join(t.TempDir(), "errors.jsonl")
+	s := NewFileStore(path, 2)
+	for i := 0; i < 7; i++ {
+		if err := s.Save(Newf("failure %d", i)); err != nil {
+			t.Fatal(err)
+		}
+		lines, err := s.read()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(lines) >= 2*s.keep {
+			t.Errorf("after %d saves the file holds %d chains, want it compacted below %d", i+1, len(lines), 2*s.keep)
+		}
+	}
+	recent, err := s.LoadRecent()
+	if err != nil || len(recent) != 2 || recent[0].msg != "failure 5" || recent[1].msg != "failure 6" {
+		t.Errorf("LoadRecent() = %v, %v, want the two most recent", recent, err)
+	}
+}
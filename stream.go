@@ -0,0 +1,155 @@
+package eros
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// defaultRenderLimit - the most links WriteChain and WriteJSON render by default
+const defaultRenderLimit = 1000
+
+// RenderLimit - sets the most links WriteChain and WriteJSON render before skipping
+// to the root cause, a safeguard against pathological chains. 0 restores the default
+func RenderLimit(links int) Setting {
+	return func(c *config) {
+		c.renderLimit = links
+	}
+}
+
+// renderLimit - the render limit in effect
+func renderLimit() int {
+	if n := conf().renderLimit; n > 0 {
+		return n
+	}
+	return defaultRenderLimit
+}
+
+// WriteTo - implement io.WriterTo, see WriteChain
+func (e *Error) WriteTo(w io.Writer) (int64, error) {
+	return WriteChain(w, e)
+}
+
+// WriteChain - renders err's chain to w one link per line, as it's walked, so a huge
+// chain never has to be built up as one string in memory. Each line holds the link's
+// own message, its code and its fields. Past the RenderLimit the links are only
+// counted and the root cause is written last, so it's never lost
+func WriteChain(w io.Writer, err error) (int64, error) {
+	var n int64
+	werr := walkLimited(err, func(i int, l error) error {
+		prefix := "caused by: "
+		if i == 0 {
+			prefix = ""
+		}
+		m, err := fmt.Fprintf(w, "%s%s\n", prefix, linkLine(l))
+		n += int64(m)
+		return err
+	}, func(omitted int) error {
+		m, err := fmt.Fprintf(w, "... %d more links ...\n", omitted)
+		n += int64(m)
+		return err
+	})
+	return n, werr
+}
+
+// jsonLink - one link of the chain as WriteJSON encodes it
+type jsonLink struct {
+	Message  string                 `json:"message"`
+	Code     string                 `json:"code,omitempty"`
+	Fields   map[string]interface{} `json:"fields,omitempty"`
+	ID       string                 `json:"id,omitempty"`
+	Time     *time.Time             `json:"time,omitempty"`
+	Severity int                    `json:"severity,omitempty"`
+}
+
+// WriteJSON - streams err's chain to w as {"links":[...],"omitted":n}, encoding each
+// link as it's walked. Links past the RenderLimit are omitted, bar the root cause
+// which is always the last link. A link whose fields can't be encoded (e.g. a chan)
+// has them replaced by a "fields_error" field saying why
+func WriteJSON(w io.Writer, err error) error {
+	if _, werr := io.WriteString(w, `{"links":[`); werr != nil {
+		return werr
+	}
+	omitted := 0
+	werr := walkLimited(err, func(i int, l error) error {
+		jl := jsonLink{Message: message(l), Code: linkCode(l)}
+		if e := link(l); e != nil {
+			jl.Fields, jl.ID, jl.Severity = e.fields, e.id, int(e.severity)
+			if !e.at.IsZero() {
+				jl.Time = &e.at
+			}
+		}
+		b, err := json.Marshal(jl)
+		if err != nil {
+			// only fields can fail to encode; say so rather than leave the json cut off
+			jl.Fields = map[string]interface{}{"fields_error": err.Error()}
+			b, _ = json.Marshal(jl)
+		}
+		if i > 0 {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+		_, err = w.Write(b)
+		return err
+	}, func(n int) error {
+		omitted = n
+		return nil
+	})
+	if werr != nil {
+		return werr
+	}
+	_, werr = fmt.Fprintf(w, `],"omitted":%d}`, omitted)
+	return werr
+}
+
+// walkLimited - calls fn for every link in err's chain up to the render limit, then
+// skipped with the number of links left out and fn once more for the root cause
+func walkLimited(err error, fn func(i int, l error) error, skipped func(omitted int) error) error {
+	var links []error
+	walk(err, func(l error) bool {
+		links = append(links, l)
+		return true
+	})
+	limit := renderLimit()
+	for i, l := range links {
+		if i == limit {
+			break
+		}
+		if werr := fn(i, l); werr != nil {
+			return werr
+		}
+	}
+	if len(links) <= limit {
+		return nil
+	}
+	if omitted := len(links) - limit - 1; omitted > 0 {
+		if werr := skipped(omitted); werr != nil {
+			return werr
+		}
+	}
+	return fn(limit, links[len(links)-1])
+}
+
+// linkLine - a single line rendering of one link; message, code and sorted fields
+func linkLine(err error) string {
+	var sb strings.Builder
+	sb.WriteString(message(err))
+	if code := linkCode(err); code != "" {
+		fmt.Fprintf(&sb, " [%s]", code)
+	}
+	if e := link(err); e != nil && len(e.fields) > 0 {
+		keys := make([]string, 0, len(e.fields))
+		for k := range e.fields {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Fprintf(&sb, " %s=%v", k, e.fields[k])
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,80 @@
+package eros
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+// longChain - a chain of n eros links on top of a root cause
+func longChain(n int) *Error {
+	err := Wrap(errors.New("root cause"), "link 0")
+	for i := 1; i < n; i++ {
+		err = Wrap(err, fmt.Sprintf("link %d", i))
+	}
+	return err
+}
+
+func TestWriteChain(t *testing.T) {
+	var buf bytes.Buffer
+	err := Wrap(New("inner").WithCode("E1").WithField("id", 7), "outer")
+	n, werr := err.WriteTo(&buf)
+	want := "outer\ncaused by: inner [E1] id=7\n"
+	if werr != nil || buf.String() != want || n != int64(len(want)) {
+		t.Errorf("WriteTo() = %q, %d, %v, want %q", buf.String(), n, werr, want)
+	}
+
+	Configure(RenderLimit(3))
+	defer Configure(RenderLimit(0))
+	buf.Reset()
+	WriteChain(&buf, longChain(10000))
+	want = "link 9999\ncaused by: link 9998\ncaused by: link 9997\n... 9997 more links ...\ncaused by: root cause\n"
+	if buf.String() != want {
+		t.Errorf("WriteChain() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	Configure(RenderLimit(2))
+	defer Configure(RenderLimit(0))
+
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, longChain(5)); err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Links   []jsonLink `json:"links"`
+		Omitted int        `json:"omitted"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("WriteJSON() wrote invalid json %s: %v", buf.String(), err)
+	}
+	var msgs []string
+	for _, l := range got.Links {
+		msgs = append(msgs, l.Message)
+	}
+	if strings.Join(msgs, ",") != "link 4,link 3,root cause" || got.Omitted != 3 {
+		t.Errorf("WriteJSON() = %s", buf.String())
+	}
+}
+
+func TestWriteJSONBadField(t *testing.T) {
+	var buf bytes.Buffer
+	err := Wrap(New("root cause").WithField("ch", make(chan int)), "outer")
+	if werr := WriteJSON(&buf, err); werr != nil {
+		t.Fatal(werr)
+	}
+	var got struct {
+		Links []jsonLink `json:"links"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("WriteJSON() wrote invalid json %s: %v", buf.String(), err)
+	}
+	if len(got.Links) != 2 || got.Links[1].Fields["fields_error"] == nil {
+		t.Errorf("WriteJSON() = %s, want the bad fields replaced", buf.String())
+	}
+}
@@ -0,0 +1,126 @@
+package eros
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Pattern - what a chain looks like when a given remediation applies, see
+// RegisterSuggestion. A link of the chain matches when everything set matches it
+type Pattern struct {
+	// Code - the code of the link, any if empty
+	Code string
+	// Message - matched against the message of the link, any if nil
+	Message *regexp.Regexp
+	// Package - the prefix of the function of a frame in the link's stack, e.g.
+	// "database/sql.", any if empty
+	Package string
+	// Hint - what to do about it, for an operator
+	Hint string
+}
+
+// Suggestion - a remediation hint for a chain, see Suggest
+type Suggestion struct {
+	// Hint - what to do about it
+	Hint string
+	// Err - the link of the chain the hint is about
+	Err error
+}
+
+var (
+	patternsMu sync.RWMutex
+	patterns   []*Pattern
+)
+
+// RegisterSuggestion - adds p to the patterns Suggest matches chains against, after
+// those already registered. A starter set for common os, net and database/sql
+// failures is registered to begin with. Returns a func removing p again
+func RegisterSuggestion(p Pattern) (remove func()) {
+	pp := &p
+	patternsMu.Lock()
+	defer patternsMu.Unlock()
+	patterns = append(patterns, pp)
+	return func() {
+		patternsMu.Lock()
+		defer patternsMu.Unlock()
+		for i, v := range patterns {
+			if v == pp {
+				patterns = append(patterns[:i:i], patterns[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Suggest - the hints of the patterns err's chain matches, in the order they were
+// registered; each pattern gives one, about the first link it matches. A diagnosis
+// aid for operators, it can only be as good as the patterns; nil for nil
+func Suggest(err error) []Suggestion {
+	if err == nil {
+		return nil
+	}
+	patternsMu.RLock()
+	defer patternsMu.RUnlock()
+	var res []Suggestion
+	for _, p := range patterns {
+		walk(err, func(l error) bool {
+			if p.matches(l) {
+				res = append(res, Suggestion{Hint: p.Hint, Err: l})
+				return false
+			}
+			return true
+		})
+	}
+	return res
+}
+
+// matches - whether the one link l matches p
+func (p Pattern) matches(l error) bool {
+	if p.Code != "" && linkCode(l) != p.Code {
+		return false
+	}
+	if p.Message != nil && !p.Message.MatchString(message(l)) {
+		return false
+	}
+	if p.Package != "" {
+		for _, f := range linkFrames(l) {
+			if strings.HasPrefix(f.Function, p.Package) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func init() {
+	for _, p := range []Pattern{
+		{Message: regexp.MustCompile(`no such file or directory|cannot find the (file|path)`),
+			Hint: "check the path exists and is spelled as the process sees it, relative paths are to its working directory"},
+		{Message: regexp.MustCompile(`permission denied|access is denied`),
+			Hint: "check the user the process runs as may access the file or port; ports below 1024 need privileges"},
+		{Message: regexp.MustCompile(`too many open files`),
+			Hint: "raise the file descriptor limit (ulimit -n) or look for leaked files, connections or response bodies"},
+		{Message: regexp.MustCompile(`connection refused`),
+			Hint: "nothing listens at the address; check the service is up and the host and port are right"},
+		{Message: regexp.MustCompile(`no such host`),
+			Hint: "the host name doesn't resolve; check its spelling and the DNS configuration"},
+		{Message: regexp.MustCompile(`i/o timeout|deadline exceeded`),
+			Hint: "the other end was too slow or unreachable; check the network path, firewalls and its load"},
+		{Message: regexp.MustCompile(`address already in use`),
+			Hint: "another process (or another instance of this one) holds the port"},
+		{Message: regexp.MustCompile(`broken pipe|connection reset by peer`),
+			Hint: "the other end closed the connection; check its logs and any idle timeouts between the two"},
+		{Message: regexp.MustCompile(`x509: `),
+			Hint: "the TLS certificate isn't trusted or doesn't match the host; check the CA bundle and the name dialled"},
+		{Message: regexp.MustCompile(`sql: no rows in result set`),
+			Hint: "the query matched nothing; a lookup of something that may not exist should be coded NotFound"},
+		{Message: regexp.MustCompile(`sql: database is closed`),
+			Hint: "the *sql.DB was closed before this query; check the order of shutdown"},
+		{Message: regexp.MustCompile(`sql: connection is already closed|bad connection`),
+			Hint: "the database connection was dropped; check the database's logs and the pool's connection lifetime"},
+	} {
+		RegisterSuggestion(p)
+	}
+}
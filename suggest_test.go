@@ -0,0 +1,50 @@
+package eros
+
+import (
+	"database/sql"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestSuggest(t *testing.T) {
+	if Suggest(nil) != nil {
+		t.Errorf("expected no suggestions for nil")
+	}
+
+	_, ferr := os.Open("/no/such/eros/file")
+	got := Suggest(Wrap(ferr, "loading config"))
+	if len(got) != 1 || !strings.Contains(got[0].Hint, "path exists") || !os.IsNotExist(got[0].Err) {
+		t.Errorf("Suggest() = %+v, want the starter hint about the missing file", got)
+	}
+
+	if got := Suggest(Wrap(sql.ErrNoRows, "loading user")); len(got) != 1 || !strings.Contains(got[0].Hint, "NotFound") {
+		t.Errorf("Suggest() = %+v, want the starter hint about no rows", got)
+	}
+
+	remove := RegisterSuggestion(Pattern{
+		Code:    Unavailable,
+		Message: regexp.MustCompile(`^quota`),
+		Package: "github.com/dawenga/eros.",
+		Hint:    "raise the quota",
+	})
+	defer remove()
+	Configure(CaptureStacks(true))
+	defer Configure(CaptureStacks(false))
+	err := New("quota exhausted").WithCode(Unavailable)
+	if got := Suggest(err); len(got) != 1 || got[0].Hint != "raise the quota" {
+		t.Errorf("Suggest() = %+v, want the registered hint", got)
+	}
+	if got := Suggest(New("quota exhausted")); len(got) != 0 {
+		t.Errorf("Suggest() = %+v, want nothing without the pattern's code", got)
+	}
+}
+
+func TestRegisterSuggestionRemove(t *testing.T) {
+	remove := RegisterSuggestion(Pattern{Code: "EROS_REMOVED", Hint: "never shown"})
+	remove()
+	if got := Suggest(New("gone").WithCode("EROS_REMOVED")); len(got) != 0 {
+		t.Errorf("Suggest() = %+v, want nothing once the pattern was removed", got)
+	}
+}
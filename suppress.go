@@ -0,0 +1,34 @@
+package eros
+
+// Suppress - sets the errors known to be benign, e.g. context.Canceled during a
+// graceful shutdown. Observers are never notified of an error with one of targets in
+// its chain, and handlers can skip them with SkipSuppressed. Suppress() with no
+// targets clears the list
+func Suppress(targets ...error) Setting {
+	return func(c *config) {
+		c.suppressed = append([]error(nil), targets...)
+	}
+}
+
+// IsSuppressed - whether err's chain holds one of the errors set by Suppress
+func IsSuppressed(err error) bool {
+	if err == nil {
+		return false
+	}
+	for _, target := range conf().suppressed {
+		if Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// SkipSuppressed - a handler passing everything but suppressed errors on to inner.
+// Meant for handlers that only report, e.g. logging, not ones that set results
+func SkipSuppressed(inner Handler) Handler {
+	return func(err *Error) {
+		if !IsSuppressed(err) {
+			inner(err)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package eros
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSuppress(t *testing.T) {
+	var observed, handled []string
+	remove := Observe(func(ev Event, err *Error) {
+		if ev == Recovered {
+			observed = append(observed, err.msg)
+		}
+	})
+	defer remove()
+	Configure(Suppress(context.Canceled))
+	defer Configure(Suppress())
+
+	for _, err := range []error{Wrap(context.Canceled, "shutting down"), New("real failure")} {
+		func() {
+			defer ErrorHandler(SkipSuppressed(func(err *Error) {
+				handled = append(handled, err.msg)
+			}))()
+			Check(err)
+		}()
+	}
+	if len(observed) != 1 || observed[0] != "real failure" {
+		t.Errorf("expected only the real failure to be observed, got %v", observed)
+	}
+	if len(handled) != 1 || handled[0] != "real failure" {
+		t.Errorf("expected only the real failure to be handled, got %v", handled)
+	}
+
+	Configure(Suppress())
+	if IsSuppressed(context.Canceled) {
+		t.Errorf("expected Suppress() to clear the list")
+	}
+}
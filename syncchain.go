@@ -0,0 +1,50 @@
+package eros
+
+import "sync"
+
+// SyncChain - a chain several goroutines build at once, see Sync. The With methods of
+// *Error change the chain in place and must not be called on the same chain from
+// several goroutines at once; those of a SyncChain may be
+type SyncChain struct {
+	mu  sync.Mutex
+	err *Error
+}
+
+// Sync - e, guarded for goroutines to add to at once, e.g. workers aggregating their
+// failures onto the same chain. e may be nil, as for WithCause. e must only be
+// changed through the SyncChain from then on
+//
+//	chain := eros.New("batch failed").Sync()
+//	for _, item := range items {
+//		go func(item Item) {
+//			if err := process(item); err != nil {
+//				chain.WithCause(err)
+//			}
+//		}(item)
+//	}
+func (e *Error) Sync() *SyncChain {
+	return &SyncChain{err: e}
+}
+
+// WithCause - appends err to the chain, see Error.WithCause
+func (s *SyncChain) WithCause(err error) *SyncChain {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = s.err.WithCause(err)
+	return s
+}
+
+// Do - calls fn with the chain, no other goroutine changing it until fn returns; for
+// the With methods of *Error the SyncChain doesn't have itself. fn mustn't keep e
+func (s *SyncChain) Do(fn func(e *Error)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(s.err)
+}
+
+// Err - the chain built so far. Only read it once the goroutines building it are done
+func (s *SyncChain) Err() *Error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
@@ -0,0 +1,34 @@
+package eros
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSyncChain(t *testing.T) {
+	chain := New("batch failed").Sync()
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			chain.WithCause(Newf("item %d failed", i))
+			chain.Do(func(e *Error) {
+				e.WithField("last", i)
+			})
+		}(i)
+	}
+	wg.Wait()
+	err := chain.Err()
+	if n := len(err.Causes()); n != 51 {
+		t.Errorf("Sync() chained %d errors, want every item's and the batch's", n)
+	}
+	if err.Count() != 50 {
+		t.Errorf("Count() = %d, want 50", err.Count())
+	}
+
+	var nilChain *Error
+	if got := nilChain.Sync().WithCause(errStepFailed).Err(); !Is(got, errStepFailed) {
+		t.Errorf("Sync() of nil = %v, want the cause", got)
+	}
+}
@@ -0,0 +1,119 @@
+package eros
+
+// A general purpose taxonomy of codes, so there's a sane set to start from rather than
+// one invented per team. Each is registered (see RegisterCode) with a message and the
+// HTTP status, gRPC code, severity and fault errors coded with it default to
+const (
+	// NotFound - what was asked for doesn't exist
+	NotFound = "NOT_FOUND"
+	// AlreadyExists - what was to be created already exists
+	AlreadyExists = "ALREADY_EXISTS"
+	// InvalidArgument - the request is malformed, whatever the state of the system
+	InvalidArgument = "INVALID_ARGUMENT"
+	// Unauthenticated - the caller didn't prove who they are
+	Unauthenticated = "UNAUTHENTICATED"
+	// PermissionDenied - the caller isn't allowed to do this
+	PermissionDenied = "PERMISSION_DENIED"
+	// Unavailable - a dependency (or we) can't serve right now, retrying may help
+	Unavailable = "UNAVAILABLE"
+	// Internal - something is broken on our side
+	Internal = "INTERNAL"
+	// DeadlineExceeded - we ran out of time before finishing
+	DeadlineExceeded = "DEADLINE_EXCEEDED"
+	// Canceled - the caller gave up on the request, typically by cancelling its context
+	Canceled = "CANCELED"
+)
+
+// gRPC status codes, as numbered by google.golang.org/grpc/codes
+const (
+	grpcOK               = 0
+	grpcCanceled         = 1
+	grpcUnknown          = 2
+	grpcInvalidArgument  = 3
+	grpcDeadlineExceeded = 4
+	grpcNotFound         = 5
+	grpcAlreadyExists    = 6
+	grpcPermissionDenied = 7
+	grpcInternal         = 13
+	grpcUnavailable      = 14
+	grpcUnauthenticated  = 16
+)
+
+func init() {
+	for code, info := range map[string]CodeInfo{
+		NotFound:         {"not found", ClientFault, Warning, 404, grpcNotFound, true},
+		AlreadyExists:    {"already exists", ClientFault, Warning, 409, grpcAlreadyExists, true},
+		InvalidArgument:  {"invalid argument", ClientFault, Warning, 400, grpcInvalidArgument, true},
+		Unauthenticated:  {"unauthenticated", ClientFault, Warning, 401, grpcUnauthenticated, true},
+		PermissionDenied: {"permission denied", ClientFault, Warning, 403, grpcPermissionDenied, true},
+		Unavailable:      {"unavailable", ServerFault, Normal, 503, grpcUnavailable, true},
+		Internal:         {"internal error", ServerFault, Normal, 500, grpcInternal, true},
+		DeadlineExceeded: {"deadline exceeded", ServerFault, Normal, 504, grpcDeadlineExceeded, true},
+		// 499, nginx's client closed request; nobody is waiting for the response anyway
+		Canceled: {"canceled", ClientFault, Warning, 499, grpcCanceled, true},
+	} {
+		RegisterCode(code, info)
+	}
+}
+
+// HTTPStatus - the HTTP status err maps to. 200 for nil. The outermost link with a
+// status of its own decides, see WithHTTPStatus, then err's code, see Code and
+// RegisterCode; a code without a status goes by its fault, 400 for the client's and
+// otherwise 500
+func HTTPStatus(err error) int {
+	if err == nil {
+		return 200
+	}
+	status := 0
+	walk(err, func(l error) bool {
+		status = linkStatus(l)
+		return status == 0
+	})
+	if status != 0 {
+		return status
+	}
+	info, _ := LookupCode(Code(err))
+	switch {
+	case info.HTTPStatus != 0:
+		return info.HTTPStatus
+	case info.Fault == ClientFault:
+		return 400
+	}
+	return 500
+}
+
+// GRPCCode - the gRPC status code err maps to by its code, see Code and RegisterCode.
+// OK for nil, and Unknown for a code without one
+func GRPCCode(err error) int {
+	if err == nil {
+		return grpcOK
+	}
+	if info, _ := LookupCode(Code(err)); info.GRPCCode != 0 {
+		return info.GRPCCode
+	}
+	return grpcUnknown
+}
+
+// WithHTTPStatus - sets the HTTP status this link maps to, overriding what its code or
+// those below it say. This is nil safe
+func (e *Error) WithHTTPStatus(code int) *Error {
+	if e != nil {
+		e.status = code
+	}
+	return e
+}
+
+// linkStatus - the HTTP status this one link in the chain says it is, 0 if none; set
+// with WithHTTPStatus, or by its HTTPStatus() int or StatusCode() int if it isn't ours
+func linkStatus(err error) int {
+	if e := link(err); e != nil {
+		return e.status
+	}
+	switch s := err.(type) {
+	case interface{ HTTPStatus() int }:
+		return s.HTTPStatus()
+	case interface{ StatusCode() int }:
+		return s.StatusCode()
+	}
+	return 0
+}
@@ -0,0 +1,91 @@
+package eros
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestTaxonomy(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		http     int
+		grpc     int
+		severity Severity
+		fault    Fault
+	}{
+		{
+			"Test no error",
+			nil,
+			200,
+			0,
+			Normal,
+			Unclassified,
+		},
+		{
+			"Test a client code",
+			New("no such user").WithCode(NotFound),
+			404,
+			5,
+			Warning,
+			ClientFault,
+		},
+		{
+			"Test a server code deep in the chain",
+			errors.Wrap(New("db down").WithCode(Unavailable), "loading user"),
+			503,
+			14,
+			Normal,
+			ServerFault,
+		},
+		{
+			"Test an explicit severity wins over the code's",
+			New("no such user").WithCode(NotFound).WithSeverity(Fatal),
+			404,
+			5,
+			Fatal,
+			ClientFault,
+		},
+		{
+			"Test a code outside the taxonomy",
+			New("boom").WithCode("TEST_OTHER"),
+			500,
+			2,
+			Normal,
+			Unclassified,
+		},
+		{
+			"Test an explicit status wins over the code's",
+			Wrap(New("rate limited").WithHTTPStatus(429), "calling upstream").WithCode(Unavailable),
+			429,
+			14,
+			Normal,
+			ServerFault,
+		},
+		{
+			"Test an explicit status decides the fault",
+			Wrap(New("gone").WithHTTPStatus(410), "loading user"),
+			410,
+			2,
+			Normal,
+			ClientFault,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HTTPStatus(tt.err); got != tt.http {
+				t.Errorf("HTTPStatus() = %v, want %v", got, tt.http)
+			}
+			if got := GRPCCode(tt.err); got != tt.grpc {
+				t.Errorf("GRPCCode() = %v, want %v", got, tt.grpc)
+			}
+			if got := SeverityOf(tt.err); got != tt.severity {
+				t.Errorf("SeverityOf() = %v, want %v", got, tt.severity)
+			}
+			if got := FaultOf(tt.err); got != tt.fault {
+				t.Errorf("FaultOf() = %v, want %v", got, tt.fault)
+			}
+		})
+	}
+}
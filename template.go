@@ -0,0 +1,100 @@
+package eros
+
+import (
+	"strings"
+	"sync"
+	"text/template"
+)
+
+// genericPublicMessage - what PublicMessage says when nothing in the chain was meant
+// for the public
+const genericPublicMessage = "an internal error occurred"
+
+var (
+	templatesMu sync.RWMutex
+	templates   = map[string]*template.Template{}
+)
+
+// TemplateData - what a code's template can render the message of a link from
+type TemplateData struct {
+	// Message - the message the link was created with
+	Message string
+	// Code - the link's code
+	Code string
+	// Fields - the fields of the link and everything below it, see AllFields
+	Fields map[string]interface{}
+	// Cause - the message of the next link down the chain, empty at the root
+	Cause string
+}
+
+// RegisterTemplate - sets the text/template rendering the message of every link coded
+// code, so wording can be changed in one place rather than at every construction
+// site. The template is executed with TemplateData. Used by Error() and
+// PublicMessage; an empty text removes the template
+func RegisterTemplate(code, text string) error {
+	templatesMu.Lock()
+	defer templatesMu.Unlock()
+	if text == "" {
+		delete(templates, code)
+		return nil
+	}
+	t, err := template.New(code).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return Wrapf(err, "failed to parse the template of %s", code)
+	}
+	templates[code] = t
+	return nil
+}
+
+// templateFor - the template registered for code, if any
+func templateFor(code string) *template.Template {
+	if code == "" {
+		return nil
+	}
+	templatesMu.RLock()
+	defer templatesMu.RUnlock()
+	return templates[code]
+}
+
+// render - the message of this link, rendered by its code's template when there is
+// one. A template that fails falls back to the message as created
+func (e *Error) render() (string, bool) {
+	if e.rendered {
+		// already rendered by its template, e.g. by Sanitize
+		return e.msg, true
+	}
+	t := templateFor(e.code)
+	if t == nil {
+		return e.text(), false
+	}
+	data := TemplateData{Message: e.text(), Code: e.code, Fields: e.AllFields()}
+	if next := e.Unwrap(); next != nil {
+		data.Cause = message(next)
+	}
+	var sb strings.Builder
+	if err := t.Execute(&sb, data); err != nil {
+		return e.text(), false
+	}
+	return sb.String(), true
+}
+
+// PublicMessage - a message fit for whoever is on the other end of an API; that of the
+// outermost link whose code has a template, otherwise a generic one. Messages made
+// without a template may hold anything, so they are never shown. If the chain has a
+// reference it's given too, see WithReference
+func PublicMessage(err error) string {
+	msg := genericPublicMessage
+	walk(err, func(l error) bool {
+		if e := link(l); e != nil {
+			if m, ok := e.render(); ok {
+				msg = m
+				return false
+			}
+		}
+		return true
+	})
+	if ref := Reference(err); ref != "" {
+		msg += " (reference " + ref + ")"
+	}
+	return msg
+}
@@ -0,0 +1,30 @@
+package eros
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRegisterTemplate(t *testing.T) {
+	if err := RegisterTemplate("TEST_QUOTA", `{{.Fields.user}} is over quota ({{.Cause}})`); err != nil {
+		t.Fatal(err)
+	}
+	defer RegisterTemplate("TEST_QUOTA", "")
+
+	err := Wrap(New("disk full"), "failed to save upload").WithCode("TEST_QUOTA").WithField("user", "bob")
+	if got := PublicMessage(err); got != "bob is over quota (disk full)" {
+		t.Errorf("PublicMessage() = %q", got)
+	}
+	if !strings.Contains(err.Error(), " bob is over quota (disk full) (cause count 1)") {
+		t.Errorf("Error() = %q, want the rendered message", err.Error())
+	}
+	if got := PublicMessage(Wrap(err, "handling request")); got != "bob is over quota (disk full)" {
+		t.Errorf("PublicMessage() of a wrapped error = %q", got)
+	}
+	if got := PublicMessage(New("select * from users failed")); got != genericPublicMessage {
+		t.Errorf("PublicMessage() without a template = %q, want the generic message", got)
+	}
+	if err := RegisterTemplate("TEST_BAD", "{{.Nope"); err == nil {
+		t.Errorf("expected an error for a bad template")
+	}
+}
@@ -0,0 +1,92 @@
+package eros
+
+// flag - a yes or no a link may or may not say
+type flag int8
+
+const (
+	unset flag = iota
+	yes
+	no
+)
+
+// flagOf - b as a flag that's set
+func flagOf(b bool) flag {
+	if b {
+		return yes
+	}
+	return no
+}
+
+// wire - f as it's encoded, nil when unset
+func (f flag) wire() *bool {
+	if f == unset {
+		return nil
+	}
+	b := f == yes
+	return &b
+}
+
+// flagFromWire - the flag b encodes, see wire
+func flagFromWire(b *bool) flag {
+	if b == nil {
+		return unset
+	}
+	return flagOf(*b)
+}
+
+// WithTimeout - declares whether this link in the chain is a timeout, see IsTimeout.
+// This is nil safe
+func (e *Error) WithTimeout(timeout bool) *Error {
+	if e != nil {
+		e.timeout = flagOf(timeout)
+	}
+	return e
+}
+
+// WithTemporary - declares whether this link in the chain is temporary, see
+// IsTemporary. This is nil safe
+func (e *Error) WithTemporary(temporary bool) *Error {
+	if e != nil {
+		e.temporary = flagOf(temporary)
+	}
+	return e
+}
+
+// IsTimeout - whether err is a timeout. The chain is walked outermost first and the
+// first link to say decides; ours by WithTimeout, others by a Timeout() bool method,
+// as net.Error (and context.DeadlineExceeded) has
+func IsTimeout(err error) bool {
+	return decide(err, func(e *Error) flag { return e.timeout }, func(l error) flag {
+		if t, ok := l.(interface{ Timeout() bool }); ok {
+			return flagOf(t.Timeout())
+		}
+		return unset
+	})
+}
+
+// IsTemporary - whether err is temporary. The chain is walked outermost first and
+// the first link to say decides; ours by WithTemporary, others by a Temporary() bool
+// method, as net.Error has
+func IsTemporary(err error) bool {
+	return decide(err, func(e *Error) flag { return e.temporary }, func(l error) flag {
+		if t, ok := l.(interface{ Temporary() bool }); ok {
+			return flagOf(t.Temporary())
+		}
+		return unset
+	})
+}
+
+// decide - whether the outermost link in err's chain saying anything, by ours for
+// links of ours and by theirs for the rest, says yes
+func decide(err error, ours func(*Error) flag, theirs func(error) flag) bool {
+	f := unset
+	walk(err, func(l error) bool {
+		if e := link(l); e != nil {
+			f = ours(e)
+		} else {
+			f = theirs(l)
+		}
+		return f == unset
+	})
+	return f == yes
+}
@@ -0,0 +1,45 @@
+package eros
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+)
+
+// netError - a net.Error as a dialer might return it
+type netError struct {
+	timeout, temporary bool
+}
+
+func (e netError) Error() string   { return "i/o failure" }
+func (e netError) Timeout() bool   { return e.timeout }
+func (e netError) Temporary() bool { return e.temporary }
+
+var _ net.Error = netError{}
+
+func TestIsTimeout(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		timeout   bool
+		temporary bool
+	}{
+		{"Test nil", nil, false, false},
+		{"Test nothing says", Wrap(io.EOF, "reading"), false, false},
+		{"Test a net.Error deep in the chain", Wrap(Wrap(netError{true, true}, "dialing"), "calling upstream"), true, true},
+		{"Test the context deadline", Wrap(context.DeadlineExceeded, "querying"), true, true},
+		{"Test our flags", New("slow disk").WithTimeout(true).WithTemporary(true), true, true},
+		{"Test an outer link overrides", Wrap(netError{true, true}, "giving up").WithTimeout(false).WithTemporary(false), false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsTimeout(tt.err); got != tt.timeout {
+				t.Errorf("IsTimeout() = %v, want %v", got, tt.timeout)
+			}
+			if got := IsTemporary(tt.err); got != tt.temporary {
+				t.Errorf("IsTemporary() = %v, want %v", got, tt.temporary)
+			}
+		})
+	}
+}
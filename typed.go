@@ -0,0 +1,53 @@
+package eros
+
+// Has - whether an error of type T is anywhere in err's chain. Like As, a pointer in
+// the chain also matches the type it points to
+func Has[T error](err error) bool {
+	_, ok := AsType[T](err)
+	return ok
+}
+
+// AsType - the first error of type T in err's chain, As without declaring a target
+// or reflecting on it;
+//
+//	if pe, ok := eros.AsType[*os.PathError](err); ok {
+//		...
+//	}
+//
+// Like As, a pointer in the chain also matches the type it points to
+func AsType[T error](err error) (res T, found bool) {
+	walk(err, func(l error) bool {
+		res, found = linkAs[T](l)
+		return !found
+	})
+	return
+}
+
+// linkAs - this one link in the chain as a T, if it is one
+func linkAs[T error](err error) (T, bool) {
+	if t, ok := err.(T); ok {
+		return t, true
+	}
+	if p, ok := interface{}(err).(*T); ok && p != nil {
+		return *p, true
+	}
+	var zero T
+	return zero, false
+}
+
+// DepthOf - how far down err's chain the first error of type T is, 0 being err
+// itself, or -1 if there is none. Like As, a pointer in the chain also matches the
+// type it points to
+func DepthOf[T error](err error) int {
+	depth, found := 0, false
+	walk(err, func(l error) bool {
+		if _, found = linkAs[T](l); !found {
+			depth++
+		}
+		return !found
+	})
+	if !found {
+		return -1
+	}
+	return depth
+}
@@ -0,0 +1,72 @@
+package eros
+
+import (
+	"os"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestDepthOf(t *testing.T) {
+	_, openErr := os.Open("/opt/abc/baddir/file")
+	err := Wrap(errors.Wrap(openErr, "loading config"), "starting up")
+	tests := []struct {
+		name  string
+		depth func(error) int
+		has   func(error) bool
+		want  int
+	}{
+		{
+			"Test our own error on top",
+			DepthOf[*Error],
+			Has[*Error],
+			0,
+		},
+		{
+			"Test a pointer found as its value type",
+			DepthOf[Error],
+			Has[Error],
+			0,
+		},
+		{
+			"Test a wrapped stdlib error",
+			DepthOf[*os.PathError],
+			Has[*os.PathError],
+			3,
+		},
+		{
+			"Test a type not in the chain",
+			DepthOf[kindError],
+			Has[kindError],
+			-1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.depth(err); got != tt.want {
+				t.Errorf("DepthOf() = %d, want %d", got, tt.want)
+			}
+			if got := tt.has(err); got != (tt.want >= 0) {
+				t.Errorf("Has() = %v, want %v", got, tt.want >= 0)
+			}
+		})
+	}
+}
+
+func TestAsType(t *testing.T) {
+	_, openErr := os.Open("/opt/abc/baddir/file")
+	err := Wrap(errors.Wrap(openErr, "loading config"), "starting up")
+
+	if pe, ok := AsType[*os.PathError](err); !ok || pe.Path != "/opt/abc/baddir/file" {
+		t.Errorf("AsType() = %v, %v, want the PathError", pe, ok)
+	}
+	if e, ok := AsType[Error](err); !ok || e.msg != "starting up" {
+		t.Errorf("AsType() = %v, %v, want the outermost Error by value", e, ok)
+	}
+	if _, ok := AsType[kindError](err); ok {
+		t.Errorf("AsType() found a type not in the chain")
+	}
+	if _, ok := AsType[*Error](nil); ok {
+		t.Errorf("AsType() found something in nil")
+	}
+}
@@ -0,0 +1,96 @@
+package eros
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"sync/atomic"
+)
+
+/*
+	Unhandled error detection is a debug aid. With DebugUnhandled on, every Error made
+	by New or Wrap carries a flag and a finalizer; anything eros does with the error on
+	the user's behalf (Check, Handle, ErrorHandler, wrapping, chaining or rendering it
+	with Error()) sets the flag. An error collected with the flag still clear was
+	created and then silently dropped, and observers are told so with Unhandled.
+*/
+
+// DebugUnhandled - when on, errors created from then on report through the observers
+// if they are garbage collected without ever being handled. This costs a finalizer
+// per error, so it's off by default and meant for debug and test builds
+func DebugUnhandled(on bool) Setting {
+	return func(c *config) {
+		c.unhandled = on
+	}
+}
+
+// track - arms the unhandled error finalizer on e, when in debug mode
+func track(e *Error) *Error {
+	if conf().unhandled {
+		e.handled = new(int32)
+		runtime.SetFinalizer(e, func(e *Error) {
+			if atomic.LoadInt32(e.handled) == 0 {
+				notify(Unhandled, e)
+			}
+		})
+	}
+	return e
+}
+
+// mark - flags err as handled. Copies of an Error share the flag, so marking any
+// one of them marks them all
+func mark(err error) {
+	if e := link(err); e != nil && e.handled != nil {
+		atomic.StoreInt32(e.handled, 1)
+	}
+}
+
+// Deferred - the func ErrorHandler (and ErrorHandlerCtx, ErrorHandled and
+// HardenedHandler) returns, to be deferred and invoked; note the trailing ()
+//
+//	defer ErrorHandler(handler)()
+//
+// Without them ErrorHandler itself is what's deferred and nothing is recovered. With
+// DebugUnhandled on, a Deferred collected without ever having been invoked is
+// reported to the observers as Misused and on stderr, with the stack it was made at.
+// Recover can't be got wrong this way
+type Deferred func()
+
+// invocation - whether a Deferred was invoked, and where it was made
+type invocation struct {
+	invoked int32
+	stack   []uintptr
+}
+
+// watch - an invocation of the Deferred its caller is about to return, watched for
+// never being invoked. nil unless in debug mode
+func watch() *invocation {
+	if !conf().unhandled {
+		return nil
+	}
+	inv := &invocation{stack: callers(2)}
+	runtime.SetFinalizer(inv, func(inv *invocation) {
+		if atomic.LoadInt32(&inv.invoked) == 0 {
+			misused(inv)
+		}
+	})
+	return inv
+}
+
+// done - records the Deferred was invoked. This is nil safe
+func (inv *invocation) done() {
+	if inv != nil {
+		atomic.StoreInt32(&inv.invoked, 1)
+	}
+}
+
+// misused - reports a Deferred that was never invoked
+func misused(inv *invocation) {
+	err := stamp(&Error{
+		msg:      "the func returned by ErrorHandler was never invoked, is the trailing () missing?",
+		severity: Fatal,
+		stack:    inv.stack,
+	})
+	notify(Misused, err)
+	fmt.Fprintf(os.Stderr, "eros: %+v\n", err)
+}
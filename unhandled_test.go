@@ -0,0 +1,153 @@
+package eros
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDebugUnhandled(t *testing.T) {
+	dropped := make(chan string, 10)
+	remove := Observe(func(ev Event, err *Error) {
+		if ev == Unhandled {
+			dropped <- err.msg
+		}
+	})
+	defer remove()
+
+	Configure(DebugUnhandled(true))
+	func() {
+		_ = New("handled").Error()
+		_ = Wrap(New("wrapped"), "logged").Error()
+		New("dropped")
+	}()
+	Configure(DebugUnhandled(false))
+
+	deadline := time.After(5 * time.Second)
+	for {
+		runtime.GC()
+		select {
+		case msg := <-dropped:
+			if msg != "dropped" {
+				t.Fatalf("expected only the dropped error to be reported, got %q", msg)
+			}
+			return
+		case <-deadline:
+			t.Fatal("the dropped error was never reported")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestMisusedErrorHandler(t *testing.T) {
+	misused := make(chan *Error, 10)
+	remove := Observe(func(ev Event, err *Error) {
+		if ev == Misused {
+			misused <- err
+		}
+	})
+	defer remove()
+
+	Configure(DebugUnhandled(true))
+	func() {
+		defer ErrorHandler(func(*Error) {})()
+		// the footgun; ErrorHandler itself is deferred, its func never invoked
+		defer ErrorHandler(func(*Error) {})
+	}()
+	Configure(DebugUnhandled(false))
+	awaitMisused(t, misused, "TestMisusedErrorHandler")
+}
+
+func TestMisusedErrorHandlerCtx(t *testing.T) {
+	misused := make(chan *Error, 10)
+	remove := Observe(func(ev Event, err *Error) {
+		if ev == Misused {
+			misused <- err
+		}
+	})
+	defer remove()
+
+	ctx := context.Background()
+	Configure(DebugUnhandled(true))
+	func() {
+		defer ErrorHandlerCtx(ctx, func(context.Context, *Error) {})()
+		defer ErrorHandlerCtx(ctx, func(context.Context, *Error) {})
+	}()
+	Configure(DebugUnhandled(false))
+	awaitMisused(t, misused, "TestMisusedErrorHandlerCtx")
+}
+
+func TestMisusedErrorHandled(t *testing.T) {
+	misused := make(chan *Error, 10)
+	remove := Observe(func(ev Event, err *Error) {
+		if ev == Misused {
+			misused <- err
+		}
+	})
+	defer remove()
+
+	Configure(DebugUnhandled(true))
+	func() {
+		defer ErrorHandler(func(*Error) {})()
+		deferFn, _ := ErrorHandled(func(*Error) {})
+		_ = deferFn
+	}()
+	Configure(DebugUnhandled(false))
+	awaitMisused(t, misused, "TestMisusedErrorHandled")
+}
+
+func TestMisusedHardenedHandler(t *testing.T) {
+	misused := make(chan *Error, 10)
+	remove := Observe(func(ev Event, err *Error) {
+		if ev == Misused {
+			misused <- err
+		}
+	})
+	defer remove()
+
+	Configure(DebugUnhandled(true))
+	func() {
+		defer ErrorHandler(func(*Error) {})()
+		defer HardenedHandler(func(*Error) {})
+	}()
+	Configure(DebugUnhandled(false))
+	awaitMisused(t, misused, "TestMisusedHardenedHandler")
+}
+
+// awaitMisused - waits for the one misuse reported in fn, the test named
+func awaitMisused(t *testing.T, misused chan *Error, fn string) {
+	t.Helper()
+	deadline := time.After(5 * time.Second)
+	for {
+		runtime.GC()
+		select {
+		case err := <-misused:
+			frames := err.StackTrace()
+			if len(frames) == 0 || !strings.Contains(frames[0].Function, fn+".") {
+				t.Errorf("expected the misuse reported where it happened, got %v", frames)
+			}
+			select {
+			case <-misused:
+				t.Errorf("expected only the ErrorHandler missing its () reported")
+			case <-time.After(50 * time.Millisecond):
+			}
+			return
+		case <-deadline:
+			t.Fatal("the misused ErrorHandler was never reported")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestRecover(t *testing.T) {
+	var got *Error
+	func() {
+		defer Recover(func(err *Error) { got = err })
+		Check(errStepFailed)
+	}()
+	if !Is(got, errStepFailed) {
+		t.Errorf("Recover() handled %v, want the raised error", got)
+	}
+}
@@ -0,0 +1,103 @@
+package eros
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// FieldError - one field of a request or form failing one rule
+type FieldError struct {
+	// Field - the name of the field, as the client knows it
+	Field string
+	// Rule - the rule it failed, e.g. "required" or "max_length"
+	Rule string
+	// Message - what's wrong with it, for the client
+	Message string
+}
+
+// Error - implement the error interface
+func (f FieldError) Error() string {
+	return f.Field + ": " + f.Message
+}
+
+// Validation - collects the field errors of validating a request or form, so they can
+// all be reported at once rather than the first alone. The zero value is ready to use
+//
+//	var v eros.Validation
+//	v.Require(req.Name != "", "name", "required", "must be set")
+//	v.Check(validateEmail(req.Email), "email", "format")
+//	eros.Check(v.Err())
+type Validation struct {
+	errs []FieldError
+}
+
+// Require - records field failing rule with msg, unless ok. Whether ok
+func (v *Validation) Require(ok bool, field, rule, msg string) bool {
+	if !ok {
+		v.Add(field, rule, msg)
+	}
+	return ok
+}
+
+// Check - records field failing rule with err's message, if err isn't nil. Whether
+// err is nil
+func (v *Validation) Check(err error, field, rule string) bool {
+	if err != nil {
+		mark(err)
+		v.Add(field, rule, message(err))
+	}
+	return err == nil
+}
+
+// Add - records field failing rule with msg
+func (v *Validation) Add(field, rule, msg string) {
+	v.errs = append(v.errs, FieldError{Field: field, Rule: rule, Message: msg})
+}
+
+// Valid - whether nothing failed
+func (v *Validation) Valid() bool {
+	return len(v.errs) == 0
+}
+
+// Errors - the field errors, in the order they were recorded
+func (v *Validation) Errors() []FieldError {
+	return v.errs
+}
+
+// Error - implement the error interface
+func (v *Validation) Error() string {
+	msgs := make([]string, len(v.errs))
+	for i, f := range v.errs {
+		msgs[i] = f.Error()
+	}
+	return "validation failed; " + strings.Join(msgs, ", ")
+}
+
+// Err - the failures as a chain coded InvalidArgument, with each field error a cause
+// of its own carrying the fields "field" and "rule". nil if nothing failed
+func (v *Validation) Err() *Error {
+	if v.Valid() {
+		return nil
+	}
+	res := newError("validation failed", 1).WithCode(InvalidArgument)
+	for _, f := range v.errs {
+		res.WithCause(wrap(f, "invalid "+f.Field, 1).
+			WithField("field", f.Field).
+			WithField("rule", f.Rule))
+	}
+	return res
+}
+
+// Map - the messages of the field errors by field, as an API response gives them
+func (v *Validation) Map() map[string][]string {
+	res := make(map[string][]string, len(v.errs))
+	for _, f := range v.errs {
+		res[f.Field] = append(res[f.Field], f.Message)
+	}
+	return res
+}
+
+// MarshalJSON - implement json.Marshaler, as Map
+func (v *Validation) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.Map())
+}
@@ -0,0 +1,46 @@
+package eros
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestValidation(t *testing.T) {
+	var v Validation
+	if v.Err() != nil || !v.Valid() {
+		t.Fatalf("expected an empty validation to be valid")
+	}
+	v.Require(false, "name", "required", "must be set")
+	v.Require(true, "age", "min", "must be positive")
+	v.Check(New("must contain an @"), "email", "format")
+	v.Add("name", "max_length", "must be at most 64 characters")
+
+	err := v.Err()
+	if Code(err) != InvalidArgument {
+		t.Errorf("Err() = %v, want it coded InvalidArgument", err)
+	}
+	var fields []string
+	walk(err, func(l error) bool {
+		if f, ok := l.(FieldError); ok {
+			fields = append(fields, f.Field+"/"+f.Rule)
+		}
+		return true
+	})
+	want := []string{"name/required", "email/format", "name/max_length"}
+	if len(fields) != len(want) {
+		t.Fatalf("Err() chained %v, want %v", fields, want)
+	}
+	for i := range want {
+		if fields[i] != want[i] {
+			t.Errorf("Err() cause %d = %s, want %s", i, fields[i], want[i])
+		}
+	}
+
+	b, jerr := json.Marshal(&v)
+	if jerr != nil {
+		t.Fatal(jerr)
+	}
+	if string(b) != `{"email":["must contain an @"],"name":["must be set","must be at most 64 characters"]}` {
+		t.Errorf("MarshalJSON() = %s, want the messages by field", b)
+	}
+}